@@ -0,0 +1,71 @@
+package automations
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
+	"github.com/tommyhedley/fiberytsheets/internal/utils"
+)
+
+func Execute(client *qbtime.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type action struct {
+			Action string            `json:"action"`
+			Args   map[string]string `json:"args"`
+		}
+		type parameters struct {
+			Action  action `json:"action"`
+			Account struct {
+				AccessToken string `json:"access_token"`
+			} `json:"account"`
+		}
+		type response struct {
+			Message string `json:"message"`
+		}
+
+		decoder := json.NewDecoder(r.Body)
+		params := parameters{}
+		err := decoder.Decode(&params)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("unable to decode request parameters: %v", err))
+			return
+		}
+
+		switch params.Action.Action {
+		case "createUser":
+			user, err := newCreateUser(params.Action.Args)
+			if err != nil {
+				utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("invalid createUser arguments: %v", err))
+				return
+			}
+
+			users, err := client.WithToken(params.Account.AccessToken).CreateUsers(r.Context(), []qbtime.CreateUser{user})
+			if err != nil {
+				utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("error with create user request: %v", err))
+				return
+			}
+
+			var userErrors []string
+			for _, user := range users {
+				if user.StatusCode > 299 {
+					userErrors = append(userErrors, fmt.Sprintf("%s %s: %s %s", user.FirstName, user.LastName, user.StatusMessage, user.StatusExtra))
+				}
+			}
+			if len(userErrors) > 0 {
+				utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("unable to create user: %s", strings.Join(userErrors, "; ")))
+				return
+			}
+
+			utils.RespondWithJSON(w, http.StatusOK, response{
+				Message: fmt.Sprintf("created %d user(s)", len(users)),
+			})
+			return
+		default:
+			utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("unsupported action: %s", params.Action.Action))
+			return
+		}
+	}
+}
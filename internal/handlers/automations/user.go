@@ -0,0 +1,38 @@
+package automations
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
+)
+
+func newCreateUser(args map[string]string) (qbtime.CreateUser, error) {
+	names := strings.Fields(args["name"])
+	if len(names) < 2 {
+		return qbtime.CreateUser{}, fmt.Errorf("name must include a first and last name")
+	}
+
+	email := strings.TrimSpace(args["email"])
+	if email == "" {
+		return qbtime.CreateUser{}, fmt.Errorf("email is required")
+	}
+
+	user := qbtime.CreateUser{
+		Username:  email,
+		FirstName: names[0],
+		LastName:  strings.Join(names[1:], " "),
+		Email:     email,
+	}
+
+	if groupID := strings.TrimSpace(args["groupID"]); groupID != "" {
+		id, err := strconv.Atoi(groupID)
+		if err != nil {
+			return qbtime.CreateUser{}, fmt.Errorf("group ID must be a number: %w", err)
+		}
+		user.GroupID = id
+	}
+
+	return user, nil
+}
@@ -9,20 +9,29 @@ import (
 )
 
 type SyncConfig struct {
-	Types   []SyncType   `json:"types"`
-	Filters []SyncFilter `json:"filters,omitempty"`
+	Types    []SyncType      `json:"types"`
+	Filters  []SyncFilter    `json:"filters,omitempty"`
+	Webhooks *WebhooksConfig `json:"webhooks,omitempty"`
+}
+type WebhooksConfig struct {
+	Enabled bool `json:"enabled"`
 }
 type SyncType struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
 }
 type SyncFilter struct {
-	Id       string `json:"id"`
-	Title    string `json:"title"`
-	Type     string `json:"type"`
-	Datalist bool   `json:"datalist,omitempty"`
-	Optional bool   `json:"optional,omitempty"`
-	Secured  bool   `json:"secured,omitempty"`
+	Id       string         `json:"id"`
+	Title    string         `json:"title"`
+	Type     string         `json:"type"`
+	Datalist bool           `json:"datalist,omitempty"`
+	Optional bool           `json:"optional,omitempty"`
+	Secured  bool           `json:"secured,omitempty"`
+	Options  []FilterOption `json:"options,omitempty"`
+}
+type FilterOption struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
 }
 
 func Config(w http.ResponseWriter, r *http.Request) {
@@ -36,6 +45,98 @@ func Config(w http.ResponseWriter, r *http.Request) {
 				ID:   "group",
 				Name: "Group",
 			},
+			{
+				ID:   "timesheet",
+				Name: "Timesheet",
+			},
+			{
+				ID:   "jobcode",
+				Name: "Jobcode",
+			},
+			{
+				ID:   "customfield",
+				Name: "Custom Field",
+			},
+			{
+				ID:   "customfielditem",
+				Name: "Custom Field Item",
+			},
+			{
+				ID:   "schedule_calendar",
+				Name: "Schedule Calendar",
+			},
+			{
+				ID:   "schedule_event",
+				Name: "Schedule Event",
+			},
+			{
+				ID:   "time_off_request",
+				Name: "Time Off Request",
+			},
+			{
+				ID:   "time_off_request_entry",
+				Name: "Time Off Request Entry",
+			},
+			{
+				ID:   "project",
+				Name: "Project",
+			},
+			{
+				ID:   "project_note",
+				Name: "Project Note",
+			},
+			{
+				ID:   "project_activity",
+				Name: "Project Activity",
+			},
+			{
+				ID:   "location",
+				Name: "Location",
+			},
+			{
+				ID:   "geolocation",
+				Name: "Geolocation",
+			},
+			{
+				ID:   "jobcode_assignment",
+				Name: "Jobcode Assignment",
+			},
+			{
+				ID:   "file",
+				Name: "File",
+			},
+			{
+				ID:   "reminder",
+				Name: "Reminder",
+			},
+			{
+				ID:   "notification",
+				Name: "Notification",
+			},
+			{
+				ID:   "current_totals",
+				Name: "Current Totals",
+			},
+			{
+				ID:   "payroll_report",
+				Name: "Payroll Report",
+			},
+			{
+				ID:   "project_estimate",
+				Name: "Project Estimate",
+			},
+			{
+				ID:   "daily_hours",
+				Name: "Daily Hours",
+			},
+			{
+				ID:   "weekly_hours",
+				Name: "Weekly Hours",
+			},
+			{
+				ID:   "effective_settings",
+				Name: "Effective Settings",
+			},
 		},
 		Filters: []SyncFilter{
 			{
@@ -50,6 +151,66 @@ func Config(w http.ResponseWriter, r *http.Request) {
 				Type:     "datebox",
 				Optional: true,
 			},
+			{
+				Id:       "groups",
+				Title:    "Only these groups",
+				Type:     "multidropdown",
+				Datalist: true,
+				Optional: true,
+			},
+			{
+				Id:       "jobcodes",
+				Title:    "Only these jobcodes",
+				Type:     "multidropdown",
+				Datalist: true,
+				Optional: true,
+			},
+			{
+				Id:       "users",
+				Title:    "Only these users",
+				Type:     "multidropdown",
+				Datalist: true,
+				Optional: true,
+			},
+			{
+				Id:       "payrollStart",
+				Title:    "Payroll report start date, also the first day of any weekly or biweekly pay period",
+				Type:     "datebox",
+				Optional: true,
+			},
+			{
+				Id:       "payrollEnd",
+				Title:    "Payroll report end date, today will be used if empty",
+				Type:     "datebox",
+				Optional: true,
+			},
+			{
+				Id:       "payrollPeriods",
+				Title:    "Number of recent pay periods in the payroll report, replaces the payroll start and end dates",
+				Type:     "number",
+				Optional: true,
+			},
+			{
+				Id:       "payrollFrequency",
+				Title:    "Pay period frequency",
+				Type:     "list",
+				Optional: true,
+				Options: []FilterOption{
+					{Title: "Weekly", Value: "weekly"},
+					{Title: "Biweekly", Value: "biweekly"},
+					{Title: "Semimonthly", Value: "semimonthly"},
+					{Title: "Monthly", Value: "monthly"},
+				},
+			},
+			{
+				Id:       "rollupWeeks",
+				Title:    "Number of recent weeks in the daily and weekly hours rollups, up to 26, 4 will be used if empty",
+				Type:     "number",
+				Optional: true,
+			},
+		},
+		Webhooks: &WebhooksConfig{
+			Enabled: true,
 		},
 	}
 
@@ -73,6 +234,18 @@ func ValidateFilters(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	_, err = payPeriods(params.Filter, time.Now().UTC())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_, err = rollupWeeks(params.Filter)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	if params.Filter["timesheetStart"] != nil {
 		if datesString, ok := params.Filter["timesheetStart"].(string); ok {
 			_, err := time.Parse(time.RFC3339, datesString)
@@ -0,0 +1,50 @@
+package synchronizer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
+)
+
+type currentTotalsItem struct {
+	Id           string `json:"id"`
+	Name         string `json:"name"`
+	UserID       string `json:"user_id"`
+	GroupID      string `json:"group_id"`
+	JobcodeID    string `json:"jobcode_id"`
+	TimesheetID  string `json:"timesheet_id"`
+	OnTheClock   bool   `json:"on_the_clock"`
+	ShiftSeconds int    `json:"shift_seconds"`
+	DaySeconds   int    `json:"day_seconds"`
+}
+
+// currentTotalsData returns one item per user from the current totals report, the report isn't paged
+func currentTotalsData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	totals, supplemental, err := api.CurrentTotalsReport(ctx, qbtime.CurrentTotalsParams{
+		UserIds:    strings.Join(filterIds(req.Filter, "users"), ","),
+		GroupIds:   strings.Join(filterIds(req.Filter, "groups"), ","),
+		OnTheClock: "both",
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	var items []any
+
+	for _, total := range totals {
+		items = append(items, currentTotalsItem{
+			Id:           total.UserID.String(),
+			Name:         supplemental.Users[total.UserID.String()].Name,
+			UserID:       total.UserID.String(),
+			GroupID:      relatedID(total.GroupID.String()),
+			JobcodeID:    relatedID(total.JobcodeID.String()),
+			TimesheetID:  relatedID(total.TimesheetID.String()),
+			OnTheClock:   total.OnTheClock,
+			ShiftSeconds: total.ShiftSeconds,
+			DaySeconds:   total.DaySeconds,
+		})
+	}
+
+	return items, false, nil
+}
@@ -0,0 +1,345 @@
+package synchronizer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
+)
+
+// customFieldTypes maps the applies_to value of a custom field to the sync type it is added to
+var customFieldTypes = map[string]string{
+	"timesheet": "timesheet",
+	"user":      "user",
+	"jobcode":   "jobcode",
+}
+
+var customFieldTypeNames = map[string]string{
+	"timesheet": "Timesheets",
+	"user":      "Users",
+	"jobcode":   "Jobcodes",
+}
+
+// customFieldsPerItemPage is how many custom fields have their items synced in each page of customfielditems
+const customFieldsPerItemPage = 5
+
+type customfielditemItem struct {
+	Id            string `json:"id"`
+	TimeID        string `json:"timeId"`
+	CustomFieldID string `json:"customfield_id"`
+	Name          string `json:"name"`
+	ShortCode     string `json:"short_code"`
+	Active        bool   `json:"active"`
+	SyncAction    string `json:"__syncAction,omitempty"`
+}
+
+type customfieldItem struct {
+	Id         string `json:"id"`
+	TimeID     string `json:"timeId"`
+	Name       string `json:"name"`
+	ShortCode  string `json:"short_code"`
+	Type       string `json:"type"`
+	AppliesTo  string `json:"applies_to"`
+	Required   bool   `json:"required"`
+	Active     bool   `json:"active"`
+	SyncAction string `json:"__syncAction,omitempty"`
+}
+
+func customFieldKey(id string) string {
+	return "customfield_" + id
+}
+
+// hasCustomFieldTypes reports whether any of the sync types can carry custom fields
+func hasCustomFieldTypes(types []string) bool {
+	for _, syncType := range customFieldTypes {
+		if slices.Contains(types, syncType) {
+			return true
+		}
+	}
+	return false
+}
+
+// relateCustomFieldItems reports whether managed list values should relate to customfielditem
+// entities, which is only possible when that type is synced
+func relateCustomFieldItems(types []string) bool {
+	return slices.Contains(types, "customfielditem")
+}
+
+// listCustomFields returns every custom field, active or not
+func listCustomFields(ctx context.Context, api *qbtime.Client) ([]qbtime.CustomField, error) {
+	var customFields []qbtime.CustomField
+	for page := 1; ; page++ {
+		pageFields, more, err := api.ListCustomFields(ctx, qbtime.ListCustomFieldsParams{
+			Active:           "both",
+			Page:             page,
+			SupplementalData: "no",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error with customfields request: %w", err)
+		}
+		customFields = append(customFields, pageFields...)
+		if !more {
+			return customFields, nil
+		}
+	}
+}
+
+// customFieldSchema builds the fields for every custom field, keyed by sync type. Managed lists
+// become single-selects with the list items as options, or relations to the customfielditem type
+// when relateItems is set. Free-form fields become text
+func customFieldSchema(ctx context.Context, api *qbtime.Client, relateItems bool) (map[string]map[string]Field, error) {
+	customFields, err := listCustomFields(ctx, api)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := map[string]map[string]Field{}
+
+	for _, customField := range customFields {
+		syncType, ok := customFieldTypes[customField.AppliesTo]
+		if !ok {
+			continue
+		}
+
+		field := Field{
+			Name:        customField.Name,
+			Description: fmt.Sprintf("Quickbooks Time custom field %s", customField.ShortCode),
+			Type:        "text",
+		}
+
+		if customField.Type == "managed-list" && relateItems {
+			field.Relation = &Relation{
+				Cardinality:   "many-to-one",
+				Name:          customField.Name,
+				TargetName:    fmt.Sprintf("%s (%s)", customFieldTypeNames[syncType], customField.Name),
+				TargetType:    "customfielditem",
+				TargetFieldID: "id",
+			}
+		} else if customField.Type == "managed-list" {
+			options, err := customFieldOptions(ctx, api, customField.Id.String())
+			if err != nil {
+				return nil, err
+			}
+			field.SubType = "single-select"
+			field.Options = options
+		}
+
+		if schema[syncType] == nil {
+			schema[syncType] = map[string]Field{}
+		}
+		schema[syncType][customFieldKey(customField.Id.String())] = field
+	}
+
+	return schema, nil
+}
+
+func customFieldOptions(ctx context.Context, api *qbtime.Client, customFieldID string) ([]FieldOption, error) {
+	options := []FieldOption{}
+	for page := 1; ; page++ {
+		items, more, err := api.ListCustomFieldItems(ctx, qbtime.ListCustomFieldItemsParams{
+			CustomFieldID:    customFieldID,
+			Active:           "both",
+			Page:             page,
+			SupplementalData: "no",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error with customfielditems request: %w", err)
+		}
+		for _, item := range items {
+			options = append(options, FieldOption{
+				Name: item.Name,
+			})
+		}
+		if !more {
+			return options, nil
+		}
+	}
+}
+
+// customFieldItemLookup resolves managed list values to customfielditem ids, the custom fields are
+// fetched the first time a value is resolved and the items of each managed list the first time one
+// of its values is resolved
+type customFieldItemLookup struct {
+	ctx          context.Context
+	api          *qbtime.Client
+	managedLists map[string]bool
+	items        map[string]map[string]string
+}
+
+func newCustomFieldItemLookup(ctx context.Context, api *qbtime.Client, req syncRequest) *customFieldItemLookup {
+	if !relateCustomFieldItems(req.Types) {
+		return nil
+	}
+	return &customFieldItemLookup{
+		ctx:   ctx,
+		api:   api,
+		items: map[string]map[string]string{},
+	}
+}
+
+// resolve returns the id of the item named value, free-form values are returned unchanged and
+// managed list values without a matching item return no id
+func (l *customFieldItemLookup) resolve(customFieldID, value string) (string, error) {
+	if l == nil {
+		return value, nil
+	}
+
+	if l.managedLists == nil {
+		customFields, err := listCustomFields(l.ctx, l.api)
+		if err != nil {
+			return "", err
+		}
+		l.managedLists = map[string]bool{}
+		for _, customField := range customFields {
+			l.managedLists[customField.Id.String()] = customField.Type == "managed-list"
+		}
+	}
+	if !l.managedLists[customFieldID] {
+		return value, nil
+	}
+
+	items, ok := l.items[customFieldID]
+	if !ok {
+		items = map[string]string{}
+		for page := 1; ; page++ {
+			pageItems, more, err := l.api.ListCustomFieldItems(l.ctx, qbtime.ListCustomFieldItemsParams{
+				CustomFieldID:    customFieldID,
+				Active:           "both",
+				Page:             page,
+				SupplementalData: "no",
+			})
+			if err != nil {
+				return "", fmt.Errorf("error with customfielditems request: %w", err)
+			}
+			for _, item := range pageItems {
+				items[item.Name] = item.Id.String()
+			}
+			if !more {
+				break
+			}
+		}
+		l.items[customFieldID] = items
+	}
+
+	return items[value], nil
+}
+
+// withCustomFields adds a record's custom field values to its item, the item is returned
+// unchanged when the record has no values
+func withCustomFields(item any, values qbtime.CustomFieldValues, lookup *customFieldItemLookup) (any, error) {
+	if len(values) == 0 {
+		return item, nil
+	}
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode item: %w", err)
+	}
+
+	var fields map[string]any
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode item: %w", err)
+	}
+
+	for id, value := range values {
+		value, err := lookup.resolve(id, value)
+		if err != nil {
+			return nil, err
+		}
+		fields[customFieldKey(id)] = value
+	}
+
+	return fields, nil
+}
+
+func customFieldData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	customFields, more, err := api.ListCustomFields(ctx, qbtime.ListCustomFieldsParams{
+		Active:           "both",
+		Page:             req.Page,
+		SupplementalData: "no",
+		ModifiedSince:    req.ModifiedSince,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	var items []any
+
+	for _, customField := range customFields {
+		item := customfieldItem{
+			Id:        customField.Id.String(),
+			TimeID:    customField.Id.String(),
+			Name:      customField.Name,
+			ShortCode: customField.ShortCode,
+			Type:      customField.Type,
+			AppliesTo: customField.AppliesTo,
+			Required:  customField.Required,
+			Active:    customField.Active,
+		}
+		if req.Sync == "delta" {
+			item.SyncAction = "SET"
+		}
+		items = append(items, item)
+	}
+
+	return items, more, nil
+}
+
+// customFieldItemData returns the items of the managed lists in a page of custom fields, items can only be
+// listed per custom field so each page covers a few custom fields
+func customFieldItemData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	customFields, more, err := api.ListCustomFields(ctx, qbtime.ListCustomFieldsParams{
+		Active:           "both",
+		Page:             req.Page,
+		Limit:            customFieldsPerItemPage,
+		SupplementalData: "no",
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	var items []any
+
+	for _, customField := range customFields {
+		if customField.Type != "managed-list" {
+			continue
+		}
+
+		for page := 1; ; page++ {
+			customFieldItems, moreItems, err := api.ListCustomFieldItems(ctx, qbtime.ListCustomFieldItemsParams{
+				CustomFieldID:    customField.Id.String(),
+				Active:           "both",
+				Page:             page,
+				SupplementalData: "no",
+				ModifiedSince:    req.ModifiedSince,
+			})
+			if err != nil {
+				return nil, false, err
+			}
+
+			for _, customFieldItem := range customFieldItems {
+				item := customfielditemItem{
+					Id:            customFieldItem.Id.String(),
+					TimeID:        customFieldItem.Id.String(),
+					CustomFieldID: customFieldItem.CustomFieldID.String(),
+					Name:          customFieldItem.Name,
+					ShortCode:     customFieldItem.ShortCode,
+					Active:        customFieldItem.Active,
+				}
+				if req.Sync == "delta" {
+					item.SyncAction = "SET"
+				}
+				items = append(items, item)
+			}
+
+			if !moreItems {
+				break
+			}
+		}
+	}
+
+	return items, more, nil
+}
@@ -1,250 +1,297 @@
 package synchronizer
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
+	"slices"
 	"time"
 
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
 	"github.com/tommyhedley/fiberytsheets/internal/utils"
 )
 
-func Data(w http.ResponseWriter, r *http.Request) {
-	type nextPageConfig struct {
-		Page int `json:"page"`
-	}
-	type pagination struct {
-		HasNext        bool           `json:"hasNext"`
-		NextPageConfig nextPageConfig `json:"nextPageConfig"`
+// syncRequest holds the parts of a Fibery data request shared by every sync type,
+// Ids limits the request to specific objects when handling webhook notifications
+// and Location is the company's time zone that Fibery dates are read in. State is
+// carried from one page to the next, sync types can set values in it for later pages
+type syncRequest struct {
+	Types         []string
+	Filter        map[string]any
+	Page          int
+	Sync          string
+	ModifiedSince string
+	Ids           []string
+	Location      *time.Location
+	State         map[string]string
+}
+
+// location returns the company's time zone, UTC is used when it isn't known
+func (r syncRequest) location() *time.Location {
+	if r.Location == nil {
+		return time.UTC
 	}
-	type parameters struct {
-		RequestedType string         `json:"requestedType"`
-		Types         []string       `json:"types"`
-		Filter        map[string]any `json:"filter"`
-		Account       struct {
-			AccessToken string `json:"access_token"`
-		} `json:"account"`
-		LastSyncronized string                               `json:"lastSynchronizedAt"`
-		Pagination      pagination                           `json:"pagination"`
-		Schema          map[string]map[string]map[string]any `json:"schema"`
+	return r.Location
+}
+
+// today returns the company's current date
+func (r syncRequest) today() time.Time {
+	return calendarDate(time.Now(), r.location())
+}
+
+// startOfDay formats midnight of a date in the company's time zone for the API
+func (r syncRequest) startOfDay(date time.Time) string {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, r.location()).Format(qbtime.TimeLayout)
+}
+
+// calendarDate returns the date of t in location as midnight UTC, the sync code compares and formats dates in UTC
+func calendarDate(t time.Time, location *time.Location) time.Time {
+	local := t.In(location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// companyLocationFromZone returns the time zone carried from an earlier page, nil is returned when there isn't one
+func companyLocationFromZone(zone string) *time.Location {
+	if zone == "" {
+		return nil
 	}
-	type response[T any] struct {
-		Items               []T        `json:"items"`
-		Pagination          pagination `json:"pagination"`
-		SynchronizationType string     `json:"synchronizationType"`
+	location, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil
 	}
+	return location
+}
 
-	decoder := json.NewDecoder(r.Body)
-	params := parameters{}
-	err := decoder.Decode(&params)
+// companyLocation returns the company's time zone from its effective settings, UTC is used when the settings
+// can't be fetched so types that don't depend on local dates still sync
+func companyLocation(ctx context.Context, api *qbtime.Client) *time.Location {
+	settings, err := api.EffectiveSettings(ctx, "")
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("unable to decode request parameters: %v", err))
-		return
+		log.Printf("Error getting company settings, using UTC: %s", err)
+		return time.UTC
 	}
+	return settings.Location()
+}
 
-	var lastSyncronized string
+type dataFunc func(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error)
 
-	if params.LastSyncronized != "" {
-		lastSyncronizedTime, err := time.Parse(time.RFC3339, params.LastSyncronized)
-		if err != nil {
-			utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("unable to parse last sync time: %v", err))
-			return
-		}
-		lastSyncronized = lastSyncronizedTime.Format("2006-01-02T15:04:05-07:00")
+var dataTypes = map[string]dataFunc{
+	"user":                   userData,
+	"group":                  groupData,
+	"timesheet":              timesheetData,
+	"jobcode":                jobcodeData,
+	"customfield":            customFieldData,
+	"customfielditem":        customFieldItemData,
+	"schedule_calendar":      scheduleCalendarData,
+	"schedule_event":         scheduleEventData,
+	"time_off_request":       timeOffRequestData,
+	"time_off_request_entry": timeOffRequestEntryData,
+	"project":                projectData,
+	"project_note":           projectNoteData,
+	"project_activity":       projectActivityData,
+	"location":               locationData,
+	"geolocation":            geolocationData,
+	"jobcode_assignment":     jobcodeAssignmentData,
+	"file":                   fileData,
+	"reminder":               reminderData,
+	"notification":           notificationData,
+	"current_totals":         currentTotalsData,
+	"payroll_report":         payrollReportData,
+	"project_estimate":       projectEstimateData,
+	"daily_hours":            dailyHoursData,
+	"weekly_hours":           weeklyHoursData,
+	"effective_settings":     effectiveSettingsData,
+}
+
+// fullSyncTypes are always synced in full, Fibery removes items missing from a full sync
+var fullSyncTypes = map[string]bool{
+	"notification":       true,
+	"current_totals":     true,
+	"payroll_report":     true,
+	"project_estimate":   true,
+	"daily_hours":        true,
+	"weekly_hours":       true,
+	"effective_settings": true,
+}
+
+// activeParam returns the active parameter for types that only sync active objects. Deleted and deactivated
+// objects are inactive, a delta sync fetches them too so they can be removed with removedItem
+func activeParam(req syncRequest) string {
+	if req.Sync == "delta" {
+		return "both"
 	}
+	return "yes"
+}
 
-	sync := "delta"
-	if lastSyncronized == "" {
-		sync = "full"
+// removeItem is the item that removes an object from Fibery during a delta sync
+func removeItem(id string) map[string]string {
+	return map[string]string{
+		"id":           id,
+		"__syncAction": "REMOVE",
 	}
+}
 
-	var page int
+// removedItem returns the item removing an object fetched by a delta sync that is no longer synced, such as an
+// inactive object, ok is false when the object is kept
+func removedItem(req syncRequest, keep bool, id string) (any, bool) {
+	if req.Sync != "delta" || keep {
+		return nil, false
+	}
+	return removeItem(id), true
+}
 
-	if params.Pagination.NextPageConfig.Page == 0 {
-		page = 1
-	} else {
-		page = params.Pagination.NextPageConfig.Page
+// relatedID drops the 0 id the API uses for an unset relation
+func relatedID(id string) string {
+	if id == "0" {
+		return ""
 	}
+	return id
+}
 
-	switch params.RequestedType {
-	case "user":
-		var active string
+// filterIds returns the ids selected in a multi-select filter, an empty selection means everything is synced
+func filterIds(filter map[string]any, id string) []string {
+	values, ok := filter[id].([]any)
+	if !ok {
+		return nil
+	}
 
-		if val, ok := params.Filter["inactiveUsers"].(bool); ok {
-			if val {
-				active = "both"
-			} else {
-				active = "yes"
-			}
+	var ids []string
+	for _, value := range values {
+		if value, ok := value.(string); ok && value != "" {
+			ids = append(ids, value)
 		}
+	}
+	return ids
+}
 
-		type userRequest struct {
-			Active           string `url:"active"`
-			Page             int    `url:"page"`
-			SupplementalData string `url:"supplemental_data"`
-			ModifiedSince    string `url:"modified_since,omitempty"`
-		}
+// filterIncludes reports whether id passes a multi-select filter
+func filterIncludes(ids []string, id string) bool {
+	return len(ids) == 0 || slices.Contains(ids, id)
+}
 
-		type userResponse struct {
-			Id         json.Number `json:"id" type:"string"`
-			Name       string      `json:"display_name"`
-			FirstName  string      `json:"first_name"`
-			LastName   string      `json:"last_name"`
-			Active     bool        `json:"active"`
-			LastActive string      `json:"last_active"`
-			GroupID    json.Number `json:"group_id" type:"string"`
-			Email      string      `json:"email"`
+func Data(client *qbtime.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// the company's time zone is looked up with the first page and carried to the rest of the sync
+		type nextPageConfig struct {
+			Page     int               `json:"page"`
+			TimeZone string            `json:"timeZone,omitempty"`
+			State    map[string]string `json:"state,omitempty"`
+		}
+		type pagination struct {
+			HasNext        bool           `json:"hasNext"`
+			NextPageConfig nextPageConfig `json:"nextPageConfig"`
+		}
+		type parameters struct {
+			RequestedType string         `json:"requestedType"`
+			Types         []string       `json:"types"`
+			Filter        map[string]any `json:"filter"`
+			Account       struct {
+				AccessToken string `json:"access_token"`
+			} `json:"account"`
+			LastSyncronized string                               `json:"lastSynchronizedAt"`
+			Pagination      pagination                           `json:"pagination"`
+			Schema          map[string]map[string]map[string]any `json:"schema"`
+		}
+		type response struct {
+			Items               []any      `json:"items"`
+			Pagination          pagination `json:"pagination"`
+			SynchronizationType string     `json:"synchronizationType"`
 		}
 
-		type item struct {
-			Id         string `json:"id"`
-			TimeID     string `json:"timeId"`
-			DiplayName string `json:"display_name"`
-			FirstName  string `json:"first_name"`
-			LastName   string `json:"last_name"`
-			Active     bool   `json:"active"`
-			Email      string `json:"email"`
-			LastActive string `json:"last_active"`
-			SyncAction string `json:"__syncAction,omitempty"`
-			GroupID    string `json:"group_id"`
+		decoder := json.NewDecoder(r.Body)
+		params := parameters{}
+		err := decoder.Decode(&params)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("unable to decode request parameters: %v", err))
+			return
 		}
 
-		userReq := userRequest{
-			Active:           active,
-			Page:             page,
-			SupplementalData: "no",
-			ModifiedSince:    lastSyncronized,
+		ctx, cancel := client.WithBudget(r.Context())
+		defer cancel()
+
+		api := client.WithToken(params.Account.AccessToken)
+
+		location := companyLocationFromZone(params.Pagination.NextPageConfig.TimeZone)
+		if location == nil {
+			location = companyLocation(ctx, api)
 		}
 
-		users, more, requestError := utils.GetData[userRequest, userResponse](&userReq, "https://rest.tsheets.com/api/v1/users", params.Account.AccessToken, "users")
-		if requestError.Err != nil {
-			if requestError.RateLimit {
-				utils.RespondWithTryLater(w, http.StatusTooManyRequests, fmt.Sprintf("rate limit reached: %v", requestError.Err))
+		var lastSyncronized string
+
+		// snapshot types can't be fetched as changes, they are replaced on every sync
+		if params.LastSyncronized != "" && !fullSyncTypes[params.RequestedType] {
+			lastSyncronizedTime, err := time.Parse(time.RFC3339, params.LastSyncronized)
+			if err != nil {
+				utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("unable to parse last sync time: %v", err))
 				return
 			}
-			utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("error with user request: %v", requestError.Err))
-			return
+			lastSyncronized = lastSyncronizedTime.In(location).Format(qbtime.TimeLayout)
 		}
 
-		var items []item
-
-		for _, user := range users {
-			if sync == "full" {
-				items = append(items, item{
-					Id:         user.Id.String(),
-					TimeID:     user.Id.String(),
-					DiplayName: user.Name,
-					FirstName:  user.FirstName,
-					LastName:   user.LastName,
-					Active:     user.Active,
-					Email:      user.Email,
-					LastActive: user.LastActive,
-					GroupID:    user.GroupID.String(),
-				})
-			} else {
-				items = append(items, item{
-					Id:         user.Id.String(),
-					TimeID:     user.Id.String(),
-					DiplayName: user.Name,
-					FirstName:  user.FirstName,
-					LastName:   user.LastName,
-					Active:     user.Active,
-					Email:      user.Email,
-					LastActive: user.LastActive,
-					GroupID:    user.GroupID.String(),
-					SyncAction: "SET",
-				})
-			}
+		sync := "delta"
+		if lastSyncronized == "" {
+			sync = "full"
 		}
 
-		resp := response[item]{
-			Items: items,
-			Pagination: pagination{
-				HasNext: more,
-				NextPageConfig: nextPageConfig{
-					Page: page + 1,
-				},
-			},
-			SynchronizationType: sync,
-		}
+		var page int
 
-		utils.RespondWithJSON(w, http.StatusOK, resp)
-		return
-	case "group":
-		type groupRequest struct {
-			Active           string `url:"active,omitempty"`
-			Page             int    `url:"page"`
-			SupplementalData string `url:"supplemental_data"`
-			ModifiedSince    string `url:"modified_since,omitempty"`
+		if params.Pagination.NextPageConfig.Page == 0 {
+			page = 1
+		} else {
+			page = params.Pagination.NextPageConfig.Page
 		}
 
-		type groupResponse struct {
-			Id         json.Number `json:"id" type:"string"`
-			Name       string      `json:"name"`
-			Active     bool        `json:"active"`
-			SyncAction string      `json:"__syncAction,omitempty"`
+		dataType, ok := dataTypes[params.RequestedType]
+		if !ok {
+			utils.RespondWithError(w, http.StatusBadRequest, "invalid requested datatype")
+			return
 		}
 
-		type item struct {
-			Id         string `json:"id"`
-			TimeID     string `json:"timeId"`
-			Name       string `json:"name"`
-			Active     bool   `json:"active"`
-			SyncAction string `json:"__syncAction,omitempty"`
+		req := syncRequest{
+			Types:         params.Types,
+			Filter:        params.Filter,
+			Page:          page,
+			Sync:          sync,
+			ModifiedSince: lastSyncronized,
+			Location:      location,
+			State:         params.Pagination.NextPageConfig.State,
 		}
-
-		groupReq := groupRequest{
-			Page:             page,
-			SupplementalData: "no",
-			ModifiedSince:    lastSyncronized,
+		if req.State == nil {
+			req.State = map[string]string{}
 		}
 
-		groups, more, requestError := utils.GetData[groupRequest, groupResponse](&groupReq, "https://rest.tsheets.com/api/v1/groups", params.Account.AccessToken, "groups")
-		if requestError.Err != nil {
-			if requestError.RateLimit {
-				utils.RespondWithTryLater(w, http.StatusTooManyRequests, fmt.Sprintf("rate limit reached: %v", requestError.Err))
-				return
-			}
-			utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("error with user request: %v", requestError.Err))
+		items, more, err := dataType(ctx, api, req)
+		if err != nil {
+			respondWithSyncError(w, params.RequestedType, err)
 			return
 		}
 
-		var items []item
-
-		for _, group := range groups {
-			if sync == "full" {
-				items = append(items, item{
-					Id:     group.Id.String(),
-					TimeID: group.Id.String(),
-					Name:   group.Name,
-					Active: group.Active,
-				})
-			} else {
-				items = append(items, item{
-					Id:         group.Id.String(),
-					TimeID:     group.Id.String(),
-					Name:       group.Name,
-					Active:     group.Active,
-					SyncAction: "SET",
-				})
-			}
-		}
-
-		resp := response[item]{
+		utils.RespondWithJSON(w, http.StatusOK, response{
 			Items: items,
 			Pagination: pagination{
 				HasNext: more,
 				NextPageConfig: nextPageConfig{
-					Page: page + 1,
+					Page:     page + 1,
+					TimeZone: location.String(),
+					State:    req.State,
 				},
 			},
 			SynchronizationType: sync,
-		}
+		})
+	}
+}
 
-		utils.RespondWithJSON(w, http.StatusOK, resp)
+// respondWithSyncError asks Fibery to retry rate limited and temporary errors and fails the sync otherwise
+func respondWithSyncError(w http.ResponseWriter, name string, err error) {
+	if qbtime.IsRateLimit(err) {
+		utils.RespondWithTryLater(w, http.StatusTooManyRequests, fmt.Sprintf("rate limit reached: %v", err))
 		return
-	default:
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid requested datatype")
+	}
+	if qbtime.IsTemporary(err) {
+		utils.RespondWithTryLater(w, http.StatusServiceUnavailable, fmt.Sprintf("temporary error with %s request: %v", name, err))
 		return
 	}
+	utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("error with %s request: %v", name, err))
 }
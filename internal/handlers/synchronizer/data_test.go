@@ -0,0 +1,58 @@
+package synchronizer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSyncRequestDates(t *testing.T) {
+	denver := time.FixedZone("MST", -7*60*60)
+
+	tests := []struct {
+		name         string
+		location     *time.Location
+		filterStart  string
+		wantStart    time.Time
+		wantStartDay string
+	}{
+		{
+			name:         "no location",
+			filterStart:  "2024-03-10T00:00:00.000Z",
+			wantStart:    date(2024, time.March, 10),
+			wantStartDay: "2024-03-10T00:00:00+00:00",
+		},
+		{
+			name:         "start read in company time zone",
+			location:     denver,
+			filterStart:  "2024-03-10T07:00:00.000Z",
+			wantStart:    date(2024, time.March, 10),
+			wantStartDay: "2024-03-10T00:00:00-07:00",
+		},
+		{
+			name:         "utc midnight is the previous day",
+			location:     denver,
+			filterStart:  "2024-03-10T00:00:00.000Z",
+			wantStart:    date(2024, time.March, 9),
+			wantStartDay: "2024-03-09T00:00:00-07:00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := syncRequest{
+				Filter:   map[string]any{"timesheetStart": tt.filterStart},
+				Location: tt.location,
+			}
+			start, err := timesheetStart(req)
+			if err != nil {
+				t.Fatalf("timesheetStart() error: %v", err)
+			}
+			if !start.Equal(tt.wantStart) {
+				t.Errorf("timesheetStart() = %s, want %s", start, tt.wantStart)
+			}
+			if got := req.startOfDay(start); got != tt.wantStartDay {
+				t.Errorf("startOfDay() = %s, want %s", got, tt.wantStartDay)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package synchronizer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
+	"github.com/tommyhedley/fiberytsheets/internal/utils"
+)
+
+type datalistItem struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+type datalistFunc func(ctx context.Context, api *qbtime.Client, page int) ([]datalistItem, bool, error)
+
+var datalists = map[string]datalistFunc{
+	"groups":   groupDatalist,
+	"jobcodes": jobcodeDatalist,
+	"users":    userDatalist,
+}
+
+// Datalist returns the options of a datalist filter, every page of the lookup is fetched
+func Datalist(client *qbtime.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type parameters struct {
+			Types   []string `json:"types"`
+			Field   string   `json:"field"`
+			Account struct {
+				AccessToken string `json:"access_token"`
+			} `json:"account"`
+		}
+		type response struct {
+			Items []datalistItem `json:"items"`
+		}
+
+		decoder := json.NewDecoder(r.Body)
+		params := parameters{}
+		err := decoder.Decode(&params)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("unable to decode request parameters: %v", err))
+			return
+		}
+
+		datalist, ok := datalists[params.Field]
+		if !ok {
+			utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("unknown datalist field: %s", params.Field))
+			return
+		}
+
+		ctx, cancel := client.WithBudget(r.Context())
+		defer cancel()
+
+		api := client.WithToken(params.Account.AccessToken)
+		items := []datalistItem{}
+
+		for page := 1; ; page++ {
+			pageItems, more, err := datalist(ctx, api, page)
+			if err != nil {
+				if qbtime.IsTemporary(err) {
+					utils.RespondWithTryLater(w, http.StatusServiceUnavailable, fmt.Sprintf("temporary error with %s request: %v", params.Field, err))
+					return
+				}
+				utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("error with %s request: %v", params.Field, err))
+				return
+			}
+			items = append(items, pageItems...)
+			if !more {
+				break
+			}
+		}
+
+		utils.RespondWithJSON(w, http.StatusOK, response{
+			Items: items,
+		})
+	}
+}
+
+func groupDatalist(ctx context.Context, api *qbtime.Client, page int) ([]datalistItem, bool, error) {
+	groups, more, err := api.ListGroups(ctx, qbtime.ListGroupsParams{
+		Active:           "yes",
+		Page:             page,
+		SupplementalData: "no",
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	var items []datalistItem
+	for _, group := range groups {
+		items = append(items, datalistItem{
+			Title: group.Name,
+			Value: group.Id.String(),
+		})
+	}
+	return items, more, nil
+}
+
+func jobcodeDatalist(ctx context.Context, api *qbtime.Client, page int) ([]datalistItem, bool, error) {
+	jobcodes, more, err := api.ListJobcodes(ctx, qbtime.ListJobcodesParams{
+		Active:           "yes",
+		Type:             "all",
+		Page:             page,
+		SupplementalData: "no",
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	var items []datalistItem
+	for _, jobcode := range jobcodes {
+		items = append(items, datalistItem{
+			Title: jobcode.Name,
+			Value: jobcode.Id.String(),
+		})
+	}
+	return items, more, nil
+}
+
+func userDatalist(ctx context.Context, api *qbtime.Client, page int) ([]datalistItem, bool, error) {
+	users, _, more, err := api.ListUsers(ctx, qbtime.ListUsersParams{
+		Active:           "yes",
+		Page:             page,
+		SupplementalData: "no",
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	var items []datalistItem
+	for _, user := range users {
+		items = append(items, datalistItem{
+			Title: user.Name,
+			Value: user.Id.String(),
+		})
+	}
+	return items, more, nil
+}
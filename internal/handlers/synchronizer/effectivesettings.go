@@ -0,0 +1,89 @@
+package synchronizer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
+)
+
+// companySettingsID is the id of the item holding the company settings, every other item is a user's settings
+const companySettingsID = "company"
+
+// effectiveSettingsUsersPerPage keeps the settings request made for each user within Fibery's request timeout
+const effectiveSettingsUsersPerPage = 20
+
+type effectiveSettingsItem struct {
+	Id                   string   `json:"id"`
+	Name                 string   `json:"name"`
+	UserID               string   `json:"user_id"`
+	TimeZone             string   `json:"time_zone"`
+	WeekStart            string   `json:"week_start"`
+	CalculateOvertime    bool     `json:"calculate_overtime"`
+	DailyRegularHours    float64  `json:"daily_regular_hours"`
+	DailyOvertimeHours   float64  `json:"daily_overtime_hours"`
+	WeeklyRegularHours   float64  `json:"weekly_regular_hours"`
+	RequireNotes         bool     `json:"require_notes"`
+	RequireJobcode       bool     `json:"require_jobcode"`
+	RequiredCustomFields []string `json:"required_customfields"`
+}
+
+func newEffectiveSettingsItem(id, name, userID string, settings qbtime.EffectiveSettings) effectiveSettingsItem {
+	return effectiveSettingsItem{
+		Id:                   id,
+		Name:                 name,
+		UserID:               userID,
+		TimeZone:             settings.Location().String(),
+		WeekStart:            settings.Weekday().String(),
+		CalculateOvertime:    settings.Overtime.Calculate.Bool(),
+		DailyRegularHours:    settings.Overtime.DailyRegularHours.Float(),
+		DailyOvertimeHours:   settings.Overtime.DailyOvertimeHours.Float(),
+		WeeklyRegularHours:   settings.Overtime.WeeklyRegularHours.Float(),
+		RequireNotes:         settings.Timesheets.RequireNotes.Bool(),
+		RequireJobcode:       settings.Timesheets.RequireJobcode.Bool(),
+		RequiredCustomFields: settings.Timesheets.RequiredCustomFields.List(),
+	}
+}
+
+// effectiveSettingsData returns the company settings with the first page followed by the settings of each user,
+// settings aren't tracked as changes so the type is always synced in full
+func effectiveSettingsData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	var items []any
+
+	if req.Page == 1 {
+		settings, err := api.EffectiveSettings(ctx, "")
+		if err != nil {
+			return nil, false, err
+		}
+		items = append(items, newEffectiveSettingsItem(companySettingsID, "Company", "", settings))
+	}
+
+	active := "yes"
+	if val, ok := req.Filter["inactiveUsers"].(bool); ok && val {
+		active = "both"
+	}
+
+	users, _, more, err := api.ListUsers(ctx, qbtime.ListUsersParams{
+		Ids:              strings.Join(filterIds(req.Filter, "users"), ","),
+		GroupIds:         strings.Join(filterIds(req.Filter, "groups"), ","),
+		Active:           active,
+		Page:             req.Page,
+		Limit:            effectiveSettingsUsersPerPage,
+		SupplementalData: "no",
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	lookup := newUserSettingsLookup(ctx, api)
+
+	for _, user := range users {
+		settings, err := lookup.get(user.Id.String())
+		if err != nil {
+			return nil, false, err
+		}
+		items = append(items, newEffectiveSettingsItem(user.Id.String(), user.Name, user.Id.String(), settings))
+	}
+
+	return items, more, nil
+}
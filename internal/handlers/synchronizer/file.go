@@ -0,0 +1,146 @@
+package synchronizer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
+	"github.com/tommyhedley/fiberytsheets/internal/utils"
+)
+
+type fileItem struct {
+	Id               string   `json:"id"`
+	TimeID           string   `json:"timeId"`
+	FileName         string   `json:"file_name"`
+	FileDescription  string   `json:"file_description"`
+	UploadedByUserID string   `json:"uploaded_by_user_id"`
+	Size             int      `json:"size"`
+	Created          string   `json:"created"`
+	TimesheetIDs     []string `json:"timesheet_ids"`
+	ProjectNoteIDs   []string `json:"project_note_ids"`
+	File             []string `json:"file"`
+	SyncAction       string   `json:"__syncAction,omitempty"`
+}
+
+// fileURLs returns resource urls for the files, Fibery downloads them through the Resource endpoint
+func fileURLs(ids []json.Number) []string {
+	urls := []string{}
+	for _, id := range ids {
+		params := url.Values{}
+		params.Add("type", "file")
+		params.Add("id", id.String())
+		urls = append(urls, "app://resource?"+params.Encode())
+	}
+	return urls
+}
+
+func linkedIds(ids []json.Number) []string {
+	linked := []string{}
+	for _, id := range ids {
+		linked = append(linked, id.String())
+	}
+	return linked
+}
+
+func fileData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	files, more, err := api.ListFiles(ctx, qbtime.ListFilesParams{
+		Active:           activeParam(req),
+		Page:             req.Page,
+		SupplementalData: "no",
+		ModifiedSince:    req.ModifiedSince,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	var items []any
+
+	for _, file := range files {
+		if item, ok := removedItem(req, file.Active, file.Id.String()); ok {
+			items = append(items, item)
+			continue
+		}
+
+		item := fileItem{
+			Id:               file.Id.String(),
+			TimeID:           file.Id.String(),
+			FileName:         file.FileName,
+			FileDescription:  file.FileDescription,
+			UploadedByUserID: file.UploadedByUserID.String(),
+			Size:             file.Size,
+			Created:          file.Created,
+			TimesheetIDs:     linkedIds(file.LinkedObjects["timesheets"]),
+			ProjectNoteIDs:   linkedIds(file.LinkedObjects["project_notes"]),
+			File:             fileURLs([]json.Number{file.Id}),
+		}
+		if req.Sync == "delta" {
+			item.SyncAction = "SET"
+		}
+		items = append(items, item)
+	}
+
+	return items, more, nil
+}
+
+// Resource streams a file from Quickbooks Time to Fibery using the account token
+func Resource(client *qbtime.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type parameters struct {
+			Account struct {
+				AccessToken string `json:"access_token"`
+			} `json:"account"`
+			Params struct {
+				Type string `json:"type"`
+				Id   string `json:"id"`
+			} `json:"params"`
+		}
+
+		decoder := json.NewDecoder(r.Body)
+		params := parameters{}
+		err := decoder.Decode(&params)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("unable to decode request parameters: %v", err))
+			return
+		}
+
+		if params.Params.Type != "file" || params.Params.Id == "" {
+			utils.RespondWithError(w, http.StatusBadRequest, "invalid resource")
+			return
+		}
+
+		// the budget only applies to opening the download, large files keep streaming under the request's context
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		budget := time.AfterFunc(client.Retry.Budget, cancel)
+
+		body, contentType, err := client.WithToken(params.Account.AccessToken).DownloadFile(ctx, params.Params.Id)
+		if !budget.Stop() && err == nil {
+			body.Close()
+			err = qbtime.NewRequestError(fmt.Errorf("file request ran out of time: %w", ctx.Err()), 0)
+		}
+		if err != nil {
+			if qbtime.IsTemporary(err) {
+				utils.RespondWithTryLater(w, http.StatusServiceUnavailable, fmt.Sprintf("temporary error with file request: %v", err))
+				return
+			}
+			utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("error with file request: %v", err))
+			return
+		}
+		defer body.Close()
+
+		if contentType != "" {
+			w.Header().Set("Content-Type", contentType)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err = io.Copy(w, body)
+		if err != nil {
+			log.Printf("Error streaming file %s: %s", params.Params.Id, err)
+		}
+	}
+}
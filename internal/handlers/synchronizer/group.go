@@ -0,0 +1,59 @@
+package synchronizer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
+)
+
+type groupItem struct {
+	Id         string `json:"id"`
+	TimeID     string `json:"timeId"`
+	Name       string `json:"name"`
+	Active     bool   `json:"active"`
+	SyncAction string `json:"__syncAction,omitempty"`
+}
+
+func groupData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	params := qbtime.ListGroupsParams{
+		Ids:              strings.Join(req.Ids, ","),
+		Active:           activeParam(req),
+		Page:             req.Page,
+		SupplementalData: "no",
+		ModifiedSince:    req.ModifiedSince,
+	}
+
+	groups, more, err := api.ListGroups(ctx, params)
+	if err != nil {
+		return nil, false, err
+	}
+
+	groupIds := filterIds(req.Filter, "groups")
+
+	var items []any
+
+	for _, group := range groups {
+		if !filterIncludes(groupIds, group.Id.String()) {
+			continue
+		}
+
+		if item, ok := removedItem(req, group.Active, group.Id.String()); ok {
+			items = append(items, item)
+			continue
+		}
+
+		item := groupItem{
+			Id:     group.Id.String(),
+			TimeID: group.Id.String(),
+			Name:   group.Name,
+			Active: group.Active,
+		}
+		if req.Sync == "delta" {
+			item.SyncAction = "SET"
+		}
+		items = append(items, item)
+	}
+
+	return items, more, nil
+}
@@ -0,0 +1,73 @@
+package synchronizer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
+)
+
+type jobcodeItem struct {
+	Id            string  `json:"id"`
+	TimeID        string  `json:"timeId"`
+	Name          string  `json:"name"`
+	ShortCode     string  `json:"short_code"`
+	Type          string  `json:"type"`
+	ParentID      string  `json:"parent_id"`
+	HasChildren   bool    `json:"has_children"`
+	Billable      bool    `json:"billable"`
+	BillableRate  float64 `json:"billable_rate"`
+	AssignedToAll bool    `json:"assigned_to_all"`
+	Active        bool    `json:"active"`
+	SyncAction    string  `json:"__syncAction,omitempty"`
+}
+
+func jobcodeData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	jobcodes, more, err := api.ListJobcodes(ctx, qbtime.ListJobcodesParams{
+		Ids:              strings.Join(req.Ids, ","),
+		Active:           "both",
+		Type:             "all",
+		Page:             req.Page,
+		SupplementalData: "no",
+		ModifiedSince:    req.ModifiedSince,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	jobcodeIds := filterIds(req.Filter, "jobcodes")
+
+	lookup := newCustomFieldItemLookup(ctx, api, req)
+
+	var items []any
+
+	for _, jobcode := range jobcodes {
+		if !filterIncludes(jobcodeIds, jobcode.Id.String()) {
+			continue
+		}
+
+		item := jobcodeItem{
+			Id:            jobcode.Id.String(),
+			TimeID:        jobcode.Id.String(),
+			Name:          jobcode.Name,
+			ShortCode:     jobcode.ShortCode,
+			Type:          jobcode.Type,
+			ParentID:      relatedID(jobcode.ParentID.String()),
+			HasChildren:   jobcode.HasChildren,
+			Billable:      jobcode.Billable,
+			BillableRate:  jobcode.BillableRate,
+			AssignedToAll: jobcode.AssignedToAll,
+			Active:        jobcode.Active,
+		}
+		if req.Sync == "delta" {
+			item.SyncAction = "SET"
+		}
+		withFields, err := withCustomFields(item, jobcode.CustomFields, lookup)
+		if err != nil {
+			return nil, false, err
+		}
+		items = append(items, withFields)
+	}
+
+	return items, more, nil
+}
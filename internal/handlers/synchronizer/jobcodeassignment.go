@@ -0,0 +1,59 @@
+package synchronizer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
+)
+
+type jobcodeAssignmentItem struct {
+	Id         string `json:"id"`
+	TimeID     string `json:"timeId"`
+	UserID     string `json:"user_id"`
+	JobcodeID  string `json:"jobcode_id"`
+	Created    string `json:"created"`
+	SyncAction string `json:"__syncAction,omitempty"`
+}
+
+func jobcodeAssignmentData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	assignments, more, err := api.ListJobcodeAssignments(ctx, qbtime.ListJobcodeAssignmentsParams{
+		UserIds:          strings.Join(filterIds(req.Filter, "users"), ","),
+		Active:           activeParam(req),
+		Page:             req.Page,
+		SupplementalData: "no",
+		ModifiedSince:    req.ModifiedSince,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	jobcodeIds := filterIds(req.Filter, "jobcodes")
+
+	var items []any
+
+	for _, assignment := range assignments {
+		if !filterIncludes(jobcodeIds, assignment.JobcodeID.String()) {
+			continue
+		}
+
+		if item, ok := removedItem(req, assignment.Active, assignment.Id.String()); ok {
+			items = append(items, item)
+			continue
+		}
+
+		item := jobcodeAssignmentItem{
+			Id:        assignment.Id.String(),
+			TimeID:    assignment.Id.String(),
+			UserID:    assignment.UserID.String(),
+			JobcodeID: assignment.JobcodeID.String(),
+			Created:   assignment.Created,
+		}
+		if req.Sync == "delta" {
+			item.SyncAction = "SET"
+		}
+		items = append(items, item)
+	}
+
+	return items, more, nil
+}
@@ -0,0 +1,136 @@
+package synchronizer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
+)
+
+// locationValue is the value of a Fibery location field
+type locationValue struct {
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	FullAddress string  `json:"fullAddress,omitempty"`
+}
+
+type locationItem struct {
+	Id             string         `json:"id"`
+	TimeID         string         `json:"timeId"`
+	Label          string         `json:"label"`
+	Address        string         `json:"address"`
+	City           string         `json:"city"`
+	State          string         `json:"state"`
+	Zip            string         `json:"zip"`
+	Country        string         `json:"country"`
+	Location       *locationValue `json:"location,omitempty"`
+	GeofenceRadius float64        `json:"geofence_radius"`
+	SyncAction     string         `json:"__syncAction,omitempty"`
+}
+
+type geolocationItem struct {
+	Id         string         `json:"id"`
+	TimeID     string         `json:"timeId"`
+	UserID     string         `json:"user_id"`
+	Location   *locationValue `json:"location,omitempty"`
+	Accuracy   float64        `json:"accuracy"`
+	Source     string         `json:"source"`
+	Created    string         `json:"created"`
+	SyncAction string         `json:"__syncAction,omitempty"`
+}
+
+func locationData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	// supplemental data carries the geofence configs of the locations
+	locations, supplemental, more, err := api.ListLocations(ctx, qbtime.ListLocationsParams{
+		Active:           activeParam(req),
+		Page:             req.Page,
+		SupplementalData: "yes",
+		ModifiedSince:    req.ModifiedSince,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	var items []any
+
+	for _, location := range locations {
+		if item, ok := removedItem(req, location.Active, location.Id.String()); ok {
+			items = append(items, item)
+			continue
+		}
+
+		address := strings.TrimSpace(strings.Join([]string{location.Addr1, location.Addr2}, " "))
+
+		item := locationItem{
+			Id:      location.Id.String(),
+			TimeID:  location.Id.String(),
+			Label:   location.Label,
+			Address: address,
+			City:    location.City,
+			State:   location.State,
+			Zip:     location.Zip,
+			Country: location.Country,
+		}
+		// locations that haven't been geocoded have no coordinates
+		if location.Latitude != 0 || location.Longitude != 0 {
+			item.Location = &locationValue{
+				Latitude:    location.Latitude,
+				Longitude:   location.Longitude,
+				FullAddress: location.FormattedAddress,
+			}
+		}
+		if geofenceConfig, ok := supplemental.GeofenceConfigs[location.GeofenceConfigID.String()]; ok && geofenceConfig.Enabled {
+			item.GeofenceRadius = geofenceConfig.Radius
+		}
+		if req.Sync == "delta" {
+			item.SyncAction = "SET"
+		}
+		items = append(items, item)
+	}
+
+	return items, more, nil
+}
+
+// geolocationData syncs breadcrumb points recorded since the timesheet start date, points can't be changed or deleted
+func geolocationData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	modifiedSince := req.ModifiedSince
+	if modifiedSince == "" {
+		startDate, err := timesheetStart(req)
+		if err != nil {
+			return nil, false, err
+		}
+		modifiedSince = req.startOfDay(startDate)
+	}
+
+	geolocations, more, err := api.ListGeolocations(ctx, qbtime.ListGeolocationsParams{
+		Page:             req.Page,
+		SupplementalData: "no",
+		ModifiedSince:    modifiedSince,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	var items []any
+
+	for _, geolocation := range geolocations {
+		item := geolocationItem{
+			Id:     geolocation.Id.String(),
+			TimeID: geolocation.Id.String(),
+			UserID: geolocation.UserID.String(),
+			Location: &locationValue{
+				Latitude:  geolocation.Latitude,
+				Longitude: geolocation.Longitude,
+			},
+			Accuracy: geolocation.Accuracy,
+			Source:   geolocation.Source,
+			Created:  geolocation.Created,
+		}
+		if req.Sync == "delta" {
+			item.SyncAction = "SET"
+		}
+		items = append(items, item)
+	}
+
+	return items, more, nil
+}
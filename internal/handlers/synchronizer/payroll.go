@@ -0,0 +1,191 @@
+package synchronizer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
+)
+
+// payPeriodAnchor is the first day of a weekly or biweekly pay period when payrollStart isn't set
+var payPeriodAnchor = time.Date(2020, time.January, 6, 0, 0, 0, 0, time.UTC)
+
+// maxPayPeriods is the most pay periods payrollPeriods can select, each period is synced as its own page
+const maxPayPeriods = 52
+
+type payPeriod struct {
+	Start time.Time
+	End   time.Time
+}
+
+type payrollReportItem struct {
+	Id            string  `json:"id"`
+	Name          string  `json:"name"`
+	UserID        string  `json:"user_id"`
+	StartDate     string  `json:"start_date"`
+	EndDate       string  `json:"end_date"`
+	RegularHours  float64 `json:"regular_hours"`
+	OvertimeHours float64 `json:"overtime_hours"`
+	DoubleHours   float64 `json:"double_time_hours"`
+	PTOHours      float64 `json:"pto_hours"`
+	TotalHours    float64 `json:"total_hours"`
+}
+
+// filterDate returns the date selected in a date filter, read in location
+func filterDate(filter map[string]any, id string, location *time.Location) (time.Time, bool, error) {
+	val, ok := filter[id].(string)
+	if !ok || val == "" {
+		return time.Time{}, false, nil
+	}
+	date, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("unable to parse %s filter: %w", id, err)
+	}
+	return calendarDate(date, location), true, nil
+}
+
+func filterNumber(filter map[string]any, id string) (int, error) {
+	switch val := filter[id].(type) {
+	case float64:
+		return int(val), nil
+	case string:
+		if val == "" {
+			return 0, nil
+		}
+		number, err := strconv.Atoi(val)
+		if err != nil {
+			return 0, fmt.Errorf("unable to parse %s filter: %w", id, err)
+		}
+		return number, nil
+	default:
+		return 0, nil
+	}
+}
+
+// periodContaining returns the pay period of the given frequency that includes date
+func periodContaining(date, anchor time.Time, frequency string) payPeriod {
+	switch frequency {
+	case "monthly":
+		start := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return payPeriod{Start: start, End: start.AddDate(0, 1, -1)}
+	case "semimonthly":
+		if date.Day() <= 15 {
+			start := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+			return payPeriod{Start: start, End: start.AddDate(0, 0, 14)}
+		}
+		start := time.Date(date.Year(), date.Month(), 16, 0, 0, 0, 0, time.UTC)
+		return payPeriod{Start: start, End: time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, time.UTC)}
+	default:
+		days := 7
+		if frequency == "biweekly" {
+			days = 14
+		}
+		offset := int(date.Sub(anchor).Hours()/24) % days
+		if offset < 0 {
+			offset += days
+		}
+		start := date.AddDate(0, 0, -offset)
+		return payPeriod{Start: start, End: start.AddDate(0, 0, days-1)}
+	}
+}
+
+// payPeriods returns the periods selected by the payroll filters, either the last payrollPeriods periods
+// up to and including the current one, or a single period from payrollStart to payrollEnd. Dates are read in
+// the time zone of now
+func payPeriods(filter map[string]any, now time.Time) ([]payPeriod, error) {
+	today := calendarDate(now, now.Location())
+
+	start, hasStart, err := filterDate(filter, "payrollStart", now.Location())
+	if err != nil {
+		return nil, err
+	}
+	end, hasEnd, err := filterDate(filter, "payrollEnd", now.Location())
+	if err != nil {
+		return nil, err
+	}
+	count, err := filterNumber(filter, "payrollPeriods")
+	if err != nil {
+		return nil, err
+	}
+	if count > maxPayPeriods {
+		return nil, fmt.Errorf("payroll periods can't be more than %d", maxPayPeriods)
+	}
+	frequency, _ := filter["payrollFrequency"].(string)
+
+	anchor := payPeriodAnchor
+	if hasStart {
+		anchor = start
+	}
+
+	if count > 0 {
+		periods := make([]payPeriod, count)
+		period := periodContaining(today, anchor, frequency)
+		for i := count - 1; i >= 0; i-- {
+			periods[i] = period
+			period = periodContaining(period.Start.AddDate(0, 0, -1), anchor, frequency)
+		}
+		return periods, nil
+	}
+
+	if !hasStart && !hasEnd {
+		return []payPeriod{periodContaining(today, anchor, frequency)}, nil
+	}
+	if !hasStart {
+		return nil, fmt.Errorf("payroll start is required with a payroll end")
+	}
+	if !hasEnd {
+		end = today
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("payroll end is before payroll start")
+	}
+	return []payPeriod{{Start: start, End: end}}, nil
+}
+
+// payrollReportData returns the payroll report of one pay period per page
+func payrollReportData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	periods, err := payPeriods(req.Filter, time.Now().In(req.location()))
+	if err != nil {
+		return nil, false, err
+	}
+	if req.Page > len(periods) {
+		return nil, false, nil
+	}
+	period := periods[req.Page-1]
+
+	reports, supplemental, err := api.PayrollReport(ctx, qbtime.PayrollReportParams{
+		UserIds:   strings.Join(filterIds(req.Filter, "users"), ","),
+		GroupIds:  strings.Join(filterIds(req.Filter, "groups"), ","),
+		StartDate: period.Start.Format("2006-01-02"),
+		EndDate:   period.End.Format("2006-01-02"),
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	var items []any
+
+	for _, report := range reports {
+		userID := report.UserID.String()
+		startDate := period.Start.Format("2006-01-02")
+		endDate := period.End.Format("2006-01-02")
+
+		items = append(items, payrollReportItem{
+			Id:            fmt.Sprintf("%s_%s_%s", userID, startDate, endDate),
+			Name:          fmt.Sprintf("%s %s - %s", supplemental.Users[userID].Name, startDate, endDate),
+			UserID:        userID,
+			StartDate:     startDate,
+			EndDate:       endDate,
+			RegularHours:  float64(report.TotalReSeconds) / 3600,
+			OvertimeHours: float64(report.TotalOtSeconds) / 3600,
+			DoubleHours:   float64(report.TotalDtSeconds) / 3600,
+			PTOHours:      float64(report.TotalPtoSeconds) / 3600,
+			TotalHours:    float64(report.TotalWorkSeconds+report.TotalPtoSeconds) / 3600,
+		})
+	}
+
+	return items, req.Page < len(periods), nil
+}
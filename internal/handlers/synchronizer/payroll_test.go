@@ -0,0 +1,133 @@
+package synchronizer
+
+import (
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestPeriodContaining(t *testing.T) {
+	tests := []struct {
+		name      string
+		date      time.Time
+		frequency string
+		want      payPeriod
+	}{
+		{"weekly on anchor", date(2020, time.January, 6), "weekly", payPeriod{date(2020, time.January, 6), date(2020, time.January, 12)}},
+		{"weekly mid period", date(2020, time.January, 8), "weekly", payPeriod{date(2020, time.January, 6), date(2020, time.January, 12)}},
+		{"weekly last day", date(2020, time.January, 12), "weekly", payPeriod{date(2020, time.January, 6), date(2020, time.January, 12)}},
+		{"weekly before anchor", date(2020, time.January, 5), "weekly", payPeriod{date(2019, time.December, 30), date(2020, time.January, 5)}},
+		{"empty frequency is weekly", date(2024, time.March, 13), "", payPeriod{date(2024, time.March, 11), date(2024, time.March, 17)}},
+		{"biweekly last day", date(2020, time.January, 19), "biweekly", payPeriod{date(2020, time.January, 6), date(2020, time.January, 19)}},
+		{"biweekly next period", date(2020, time.January, 20), "biweekly", payPeriod{date(2020, time.January, 20), date(2020, time.February, 2)}},
+		{"biweekly before anchor", date(2019, time.December, 25), "biweekly", payPeriod{date(2019, time.December, 23), date(2020, time.January, 5)}},
+		{"semimonthly first half", date(2024, time.February, 15), "semimonthly", payPeriod{date(2024, time.February, 1), date(2024, time.February, 15)}},
+		{"semimonthly second half leap year", date(2024, time.February, 16), "semimonthly", payPeriod{date(2024, time.February, 16), date(2024, time.February, 29)}},
+		{"semimonthly second half december", date(2019, time.December, 31), "semimonthly", payPeriod{date(2019, time.December, 16), date(2019, time.December, 31)}},
+		{"monthly", date(2023, time.December, 31), "monthly", payPeriod{date(2023, time.December, 1), date(2023, time.December, 31)}},
+		{"monthly february", date(2023, time.February, 1), "monthly", payPeriod{date(2023, time.February, 1), date(2023, time.February, 28)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := periodContaining(tt.date, payPeriodAnchor, tt.frequency)
+			if !got.Start.Equal(tt.want.Start) || !got.End.Equal(tt.want.End) {
+				t.Errorf("periodContaining(%s, %q) = %s - %s, want %s - %s", tt.date.Format("2006-01-02"), tt.frequency,
+					got.Start.Format("2006-01-02"), got.End.Format("2006-01-02"),
+					tt.want.Start.Format("2006-01-02"), tt.want.End.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestPayPeriods(t *testing.T) {
+	now := time.Date(2020, time.January, 8, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		filter  map[string]any
+		want    []payPeriod
+		wantErr bool
+	}{
+		{
+			name:   "current period by default",
+			filter: map[string]any{},
+			want:   []payPeriod{{date(2020, time.January, 6), date(2020, time.January, 12)}},
+		},
+		{
+			name:   "last periods oldest first",
+			filter: map[string]any{"payrollPeriods": float64(3), "payrollFrequency": "weekly"},
+			want: []payPeriod{
+				{date(2019, time.December, 23), date(2019, time.December, 29)},
+				{date(2019, time.December, 30), date(2020, time.January, 5)},
+				{date(2020, time.January, 6), date(2020, time.January, 12)},
+			},
+		},
+		{
+			name:   "periods counted from payroll start",
+			filter: map[string]any{"payrollPeriods": "2", "payrollFrequency": "biweekly", "payrollStart": "2019-12-30T00:00:00Z"},
+			want: []payPeriod{
+				{date(2019, time.December, 16), date(2019, time.December, 29)},
+				{date(2019, time.December, 30), date(2020, time.January, 12)},
+			},
+		},
+		{
+			name:   "single range",
+			filter: map[string]any{"payrollStart": "2019-12-01T00:00:00Z", "payrollEnd": "2019-12-31T00:00:00Z"},
+			want:   []payPeriod{{date(2019, time.December, 1), date(2019, time.December, 31)}},
+		},
+		{
+			name:   "range up to today",
+			filter: map[string]any{"payrollStart": "2019-12-01T00:00:00Z"},
+			want:   []payPeriod{{date(2019, time.December, 1), date(2020, time.January, 8)}},
+		},
+		{
+			name:    "most periods",
+			filter:  map[string]any{"payrollPeriods": float64(maxPayPeriods + 1)},
+			wantErr: true,
+		},
+		{
+			name:    "huge period count",
+			filter:  map[string]any{"payrollPeriods": "1000000000000"},
+			wantErr: true,
+		},
+		{
+			name:    "end without start",
+			filter:  map[string]any{"payrollEnd": "2019-12-31T00:00:00Z"},
+			wantErr: true,
+		},
+		{
+			name:    "end before start",
+			filter:  map[string]any{"payrollStart": "2019-12-31T00:00:00Z", "payrollEnd": "2019-12-01T00:00:00Z"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := payPeriods(tt.filter, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("payPeriods() returned %d periods, want an error", len(got))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("payPeriods() error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("payPeriods() returned %d periods, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if !got[i].Start.Equal(tt.want[i].Start) || !got[i].End.Equal(tt.want[i].End) {
+					t.Errorf("period %d = %s - %s, want %s - %s", i,
+						got[i].Start.Format("2006-01-02"), got[i].End.Format("2006-01-02"),
+						tt.want[i].Start.Format("2006-01-02"), tt.want[i].End.Format("2006-01-02"))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package synchronizer
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
+)
+
+// projectsPerPage limits how many projects a page of notes or activities covers, both can only
+// be listed one project at a time
+const projectsPerPage = 10
+
+type projectItem struct {
+	Id              string `json:"id"`
+	TimeID          string `json:"timeId"`
+	JobcodeID       string `json:"jobcode_id"`
+	ParentJobcodeID string `json:"parent_jobcode_id"`
+	Name            string `json:"name"`
+	Status          string `json:"status"`
+	Description     string `json:"description"`
+	StartDate       string `json:"start_date,omitempty"`
+	DueDate         string `json:"due_date,omitempty"`
+	CompletedDate   string `json:"completed_date,omitempty"`
+	SyncAction      string `json:"__syncAction,omitempty"`
+}
+
+type projectNoteItem struct {
+	Id         string   `json:"id"`
+	TimeID     string   `json:"timeId"`
+	ProjectID  string   `json:"project_id"`
+	UserID     string   `json:"user_id"`
+	Note       string   `json:"note"`
+	Files      []string `json:"files"`
+	Created    string   `json:"created"`
+	SyncAction string   `json:"__syncAction,omitempty"`
+}
+
+type projectActivityItem struct {
+	Id           string `json:"id"`
+	TimeID       string `json:"timeId"`
+	ProjectID    string `json:"project_id"`
+	UserID       string `json:"user_id"`
+	ActivityType string `json:"activity_type"`
+	Created      string `json:"created"`
+	SyncAction   string `json:"__syncAction,omitempty"`
+}
+
+func projectData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	projects, more, err := api.ListProjects(ctx, qbtime.ListProjectsParams{
+		Active:           activeParam(req),
+		Page:             req.Page,
+		SupplementalData: "no",
+		ModifiedSince:    req.ModifiedSince,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	var items []any
+
+	for _, project := range projects {
+		if item, ok := removedItem(req, project.Active, project.Id.String()); ok {
+			items = append(items, item)
+			continue
+		}
+
+		item := projectItem{
+			Id:              project.Id.String(),
+			TimeID:          project.Id.String(),
+			JobcodeID:       relatedID(project.JobcodeID.String()),
+			ParentJobcodeID: relatedID(project.ParentJobcodeID.String()),
+			Name:            project.Name,
+			Status:          project.Status,
+			Description:     project.Description,
+			StartDate:       project.StartDate,
+			DueDate:         project.DueDate,
+			CompletedDate:   project.CompletedDate,
+		}
+		if req.Sync == "delta" {
+			item.SyncAction = "SET"
+		}
+		items = append(items, item)
+	}
+
+	return items, more, nil
+}
+
+// projectPage returns the projects covered by a page of notes or activities, a delta sync includes inactive
+// projects so the notes and activities of projects deactivated since the last sync can be removed
+func projectPage(ctx context.Context, api *qbtime.Client, req syncRequest) ([]qbtime.Project, bool, error) {
+	return api.ListProjects(ctx, qbtime.ListProjectsParams{
+		Active:           activeParam(req),
+		Page:             req.Page,
+		Limit:            projectsPerPage,
+		SupplementalData: "no",
+	})
+}
+
+// projectRemoved reports whether a project's notes and activities are removed, which is the case for projects
+// deactivated since the last sync. Projects that were already inactive are skipped
+func projectRemoved(project qbtime.Project, req syncRequest) (removed bool, skipped bool) {
+	if project.Active {
+		return false, false
+	}
+
+	modified, err := time.Parse(time.RFC3339, project.LastModified)
+	if err != nil {
+		return true, false
+	}
+	since, err := time.Parse(time.RFC3339, req.ModifiedSince)
+	if err != nil {
+		return true, false
+	}
+	if modified.Before(since) {
+		return false, true
+	}
+	return true, false
+}
+
+func projectNoteData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	projects, more, err := projectPage(ctx, api, req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var items []any
+
+	for _, project := range projects {
+		removed, skipped := projectRemoved(project, req)
+		if skipped {
+			continue
+		}
+
+		// every note of a removed project is removed, not just the ones modified since the last sync
+		modifiedSince := req.ModifiedSince
+		if removed {
+			modifiedSince = ""
+		}
+
+		for page := 1; ; page++ {
+			notes, moreNotes, err := api.ListProjectNotes(ctx, qbtime.ListProjectNotesParams{
+				ProjectID:        project.Id.String(),
+				Active:           activeParam(req),
+				Page:             page,
+				SupplementalData: "no",
+				ModifiedSince:    modifiedSince,
+			})
+			if err != nil {
+				return nil, false, err
+			}
+
+			for _, note := range notes {
+				if removed || (req.Sync == "delta" && !note.Active) {
+					items = append(items, removeItem(note.Id.String()))
+					continue
+				}
+
+				item := projectNoteItem{
+					Id:        note.Id.String(),
+					TimeID:    note.Id.String(),
+					ProjectID: note.ProjectID.String(),
+					UserID:    note.UserID.String(),
+					Note:      note.Note,
+					Files:     fileURLs(note.Files),
+					Created:   note.Created,
+				}
+				if req.Sync == "delta" {
+					item.SyncAction = "SET"
+				}
+				items = append(items, item)
+			}
+
+			if !moreNotes {
+				break
+			}
+		}
+	}
+
+	return items, more, nil
+}
+
+// projectActivityData returns each project's activity feed in chronological order
+func projectActivityData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	projects, more, err := projectPage(ctx, api, req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var items []any
+
+	for _, project := range projects {
+		removed, skipped := projectRemoved(project, req)
+		if skipped {
+			continue
+		}
+
+		modifiedSince := req.ModifiedSince
+		if removed {
+			modifiedSince = ""
+		}
+
+		var activities []qbtime.ProjectActivity
+		for page := 1; ; page++ {
+			pageActivities, moreActivities, err := api.ListProjectActivities(ctx, qbtime.ListProjectActivitiesParams{
+				ProjectID:        project.Id.String(),
+				Page:             page,
+				SupplementalData: "no",
+				ModifiedSince:    modifiedSince,
+			})
+			if err != nil {
+				return nil, false, err
+			}
+			activities = append(activities, pageActivities...)
+			if !moreActivities {
+				break
+			}
+		}
+
+		sort.SliceStable(activities, func(i, j int) bool {
+			return createdTime(activities[i].Created).Before(createdTime(activities[j].Created))
+		})
+
+		for _, activity := range activities {
+			if removed {
+				items = append(items, removeItem(activity.Id.String()))
+				continue
+			}
+
+			item := projectActivityItem{
+				Id:           activity.Id.String(),
+				TimeID:       activity.Id.String(),
+				ProjectID:    activity.ProjectID.String(),
+				UserID:       activity.UserID.String(),
+				ActivityType: activity.ActivityType,
+				Created:      activity.Created,
+			}
+			if req.Sync == "delta" {
+				item.SyncAction = "SET"
+			}
+			items = append(items, item)
+		}
+	}
+
+	return items, more, nil
+}
+
+// createdTime parses a created timestamp for sorting, unparsable timestamps sort first
+func createdTime(created string) time.Time {
+	t, err := time.Parse(time.RFC3339, created)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
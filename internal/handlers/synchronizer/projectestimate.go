@@ -0,0 +1,112 @@
+package synchronizer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
+)
+
+type projectEstimateItem struct {
+	Id             string  `json:"id"`
+	Name           string  `json:"name"`
+	ProjectID      string  `json:"project_id"`
+	JobcodeID      string  `json:"jobcode_id"`
+	EstimatedHours float64 `json:"estimated_hours"`
+	ActualHours    float64 `json:"actual_hours"`
+	VarianceHours  float64 `json:"variance_hours"`
+	OverBudget     bool    `json:"over_budget"`
+}
+
+// projectEstimateData compares each project's estimate items with the hours clocked against it,
+// a page covers the same projects as a page of project notes
+func projectEstimateData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	projects, more, err := projectPage(ctx, api, req)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(projects) == 0 {
+		return nil, more, nil
+	}
+
+	var projectIds []string
+	for _, project := range projects {
+		projectIds = append(projectIds, project.Id.String())
+	}
+
+	report, _, err := api.ProjectEstimateReport(ctx, qbtime.ProjectEstimateReportParams{
+		ProjectIds: strings.Join(projectIds, ","),
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	actualSeconds := map[string]int{}
+	for _, estimate := range report {
+		actualSeconds[estimate.ProjectID.String()] = estimate.TotalClockedSeconds
+	}
+
+	estimateProjects := map[string]string{}
+	for page := 1; ; page++ {
+		estimates, moreEstimates, err := api.ListEstimates(ctx, qbtime.ListEstimatesParams{
+			ProjectIds:       strings.Join(projectIds, ","),
+			Active:           "yes",
+			Page:             page,
+			SupplementalData: "no",
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		for _, estimate := range estimates {
+			estimateProjects[estimate.Id.String()] = estimate.ProjectID.String()
+		}
+		if !moreEstimates {
+			break
+		}
+	}
+
+	estimatedSeconds := map[string]int{}
+	if len(estimateProjects) > 0 {
+		var estimateIds []string
+		for id := range estimateProjects {
+			estimateIds = append(estimateIds, id)
+		}
+
+		for page := 1; ; page++ {
+			estimateItems, moreItems, err := api.ListEstimateItems(ctx, qbtime.ListEstimateItemsParams{
+				EstimateIds:      strings.Join(estimateIds, ","),
+				Active:           "yes",
+				Page:             page,
+				SupplementalData: "no",
+			})
+			if err != nil {
+				return nil, false, err
+			}
+			for _, estimateItem := range estimateItems {
+				estimatedSeconds[estimateProjects[estimateItem.EstimateID.String()]] += estimateItem.EstimatedSeconds
+			}
+			if !moreItems {
+				break
+			}
+		}
+	}
+
+	var items []any
+
+	for _, project := range projects {
+		estimated := float64(estimatedSeconds[project.Id.String()]) / 3600
+		actual := float64(actualSeconds[project.Id.String()]) / 3600
+
+		items = append(items, projectEstimateItem{
+			Id:             project.Id.String(),
+			Name:           project.Name,
+			ProjectID:      project.Id.String(),
+			JobcodeID:      relatedID(project.JobcodeID.String()),
+			EstimatedHours: estimated,
+			ActualHours:    actual,
+			VarianceHours:  actual - estimated,
+			OverBudget:     estimated > 0 && actual > estimated,
+		})
+	}
+
+	return items, more, nil
+}
@@ -0,0 +1,132 @@
+package synchronizer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
+)
+
+type reminderItem struct {
+	Id                  string `json:"id"`
+	TimeID              string `json:"timeId"`
+	UserID              string `json:"user_id"`
+	Scope               string `json:"scope"`
+	ReminderType        string `json:"reminder_type"`
+	DueTime             string `json:"due_time"`
+	DueDaysOfWeek       string `json:"due_days_of_week"`
+	DistributionMethods string `json:"distribution_methods"`
+	Enabled             bool   `json:"enabled"`
+	SyncAction          string `json:"__syncAction,omitempty"`
+}
+
+type notificationItem struct {
+	Id           string `json:"id"`
+	TimeID       string `json:"timeId"`
+	UserID       string `json:"user_id"`
+	Message      string `json:"message"`
+	Method       string `json:"method"`
+	DeliveryTime string `json:"delivery_time"`
+}
+
+// reminderData pages through users since reminders can only be listed by user, company-wide
+// reminders belong to user 0 and are included with the first page
+func reminderData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	users, _, more, err := api.ListUsers(ctx, qbtime.ListUsersParams{
+		Ids:              strings.Join(filterIds(req.Filter, "users"), ","),
+		Active:           "yes",
+		Page:             req.Page,
+		SupplementalData: "no",
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	var userIds []string
+	if req.Page == 1 {
+		userIds = append(userIds, "0")
+	}
+	for _, user := range users {
+		userIds = append(userIds, user.Id.String())
+	}
+
+	if len(userIds) == 0 {
+		return nil, more, nil
+	}
+
+	var items []any
+
+	for page := 1; ; page++ {
+		reminders, moreReminders, err := api.ListReminders(ctx, qbtime.ListRemindersParams{
+			UserIds:          strings.Join(userIds, ","),
+			Active:           activeParam(req),
+			Page:             page,
+			SupplementalData: "no",
+			ModifiedSince:    req.ModifiedSince,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+
+		for _, reminder := range reminders {
+			if item, ok := removedItem(req, reminder.Active, reminder.Id.String()); ok {
+				items = append(items, item)
+				continue
+			}
+
+			scope := "User"
+			if reminder.UserID.String() == "0" {
+				scope = "Company"
+			}
+
+			item := reminderItem{
+				Id:                  reminder.Id.String(),
+				TimeID:              reminder.Id.String(),
+				UserID:              relatedID(reminder.UserID.String()),
+				Scope:               scope,
+				ReminderType:        reminder.ReminderType,
+				DueTime:             reminder.DueTime,
+				DueDaysOfWeek:       reminder.DueDaysOfWeek,
+				DistributionMethods: reminder.DistributionMethods,
+				Enabled:             reminder.Enabled,
+			}
+			if req.Sync == "delta" {
+				item.SyncAction = "SET"
+			}
+			items = append(items, item)
+		}
+
+		if !moreReminders {
+			break
+		}
+	}
+
+	return items, more, nil
+}
+
+// notificationData returns the pending notifications, delivered notifications are no longer listed
+// so the type is always synced in full
+func notificationData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	notifications, more, err := api.ListNotifications(ctx, qbtime.ListNotificationsParams{
+		Page:             req.Page,
+		SupplementalData: "no",
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	var items []any
+
+	for _, notification := range notifications {
+		items = append(items, notificationItem{
+			Id:           notification.Id.String(),
+			TimeID:       notification.Id.String(),
+			UserID:       notification.UserID.String(),
+			Message:      notification.Message,
+			Method:       notification.Method,
+			DeliveryTime: notification.DeliveryTime,
+		})
+	}
+
+	return items, more, nil
+}
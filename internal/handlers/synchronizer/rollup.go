@@ -0,0 +1,275 @@
+package synchronizer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
+)
+
+// defaultRollupWeeks is how many weeks of timesheets are rolled up when rollupWeeks isn't set
+const defaultRollupWeeks = 4
+
+// maxRollupWeeks is the most weeks rollupWeeks can select
+const maxRollupWeeks = 26
+
+// rollupUsersPerPage is how many users' timesheets are rolled up in each page
+const rollupUsersPerPage = 10
+
+type dailyHoursItem struct {
+	Id         string  `json:"id"`
+	Name       string  `json:"name"`
+	UserID     string  `json:"user_id"`
+	JobcodeID  string  `json:"jobcode_id"`
+	Date       string  `json:"date"`
+	Hours      float64 `json:"hours"`
+	Timesheets int     `json:"timesheets"`
+}
+
+type weeklyHoursItem struct {
+	Id         string  `json:"id"`
+	Name       string  `json:"name"`
+	UserID     string  `json:"user_id"`
+	WeekStart  string  `json:"week_start"`
+	WeekEnd    string  `json:"week_end"`
+	Hours      float64 `json:"hours"`
+	Timesheets int     `json:"timesheets"`
+}
+
+// userSettingsLookup fetches each user's effective settings the first time they are needed
+type userSettingsLookup struct {
+	ctx      context.Context
+	api      *qbtime.Client
+	settings map[string]qbtime.EffectiveSettings
+}
+
+func newUserSettingsLookup(ctx context.Context, api *qbtime.Client) *userSettingsLookup {
+	return &userSettingsLookup{
+		ctx:      ctx,
+		api:      api,
+		settings: map[string]qbtime.EffectiveSettings{},
+	}
+}
+
+func (l *userSettingsLookup) get(userID string) (qbtime.EffectiveSettings, error) {
+	if settings, ok := l.settings[userID]; ok {
+		return settings, nil
+	}
+	settings, err := l.api.EffectiveSettings(l.ctx, userID)
+	if err != nil {
+		return qbtime.EffectiveSettings{}, fmt.Errorf("error with effective settings request: %w", err)
+	}
+	l.settings[userID] = settings
+	return settings, nil
+}
+
+// rollupTimesheet is a timesheet placed in its user's week
+type rollupTimesheet struct {
+	UserID    string
+	JobcodeID string
+	Date      time.Time
+	WeekStart time.Time
+	Seconds   int
+}
+
+// rollupWeeks returns the number of weeks in the rollup window
+func rollupWeeks(filter map[string]any) (int, error) {
+	weeks, err := filterNumber(filter, "rollupWeeks")
+	if err != nil {
+		return 0, err
+	}
+	if weeks > maxRollupWeeks {
+		return 0, fmt.Errorf("rollup weeks can't be more than %d", maxRollupWeeks)
+	}
+	if weeks <= 0 {
+		weeks = defaultRollupWeeks
+	}
+	return weeks, nil
+}
+
+// rollupTimesheets fetches the timesheets in the rollup window for one page of users, windowStart is the
+// first date that is fully covered. Timesheet dates are already the user's local date, so each user's
+// settings are only looked up for their week start, once per sync when their page is requested
+func rollupTimesheets(ctx context.Context, api *qbtime.Client, req syncRequest) ([]rollupTimesheet, qbtime.Supplemental, time.Time, bool, error) {
+	weeks, err := rollupWeeks(req.Filter)
+	if err != nil {
+		return nil, qbtime.Supplemental{}, time.Time{}, false, err
+	}
+
+	windowStart := req.today().AddDate(0, 0, -7*weeks)
+
+	startDate, err := timesheetStart(req)
+	if err != nil {
+		return nil, qbtime.Supplemental{}, time.Time{}, false, err
+	}
+	if startDate.After(windowStart) {
+		windowStart = startDate
+	}
+
+	users, _, moreUsers, err := api.ListUsers(ctx, qbtime.ListUsersParams{
+		Ids:              strings.Join(filterIds(req.Filter, "users"), ","),
+		GroupIds:         strings.Join(filterIds(req.Filter, "groups"), ","),
+		Active:           "both",
+		Page:             req.Page,
+		Limit:            rollupUsersPerPage,
+		SupplementalData: "no",
+	})
+	if err != nil {
+		return nil, qbtime.Supplemental{}, time.Time{}, false, err
+	}
+
+	supplemental := qbtime.Supplemental{
+		Users:    map[string]qbtime.User{},
+		Jobcodes: map[string]qbtime.Jobcode{},
+	}
+
+	var userIds []string
+	for _, user := range users {
+		userIds = append(userIds, user.Id.String())
+		supplemental.Users[user.Id.String()] = user
+	}
+	if len(userIds) == 0 {
+		return nil, supplemental, windowStart, false, nil
+	}
+
+	settings := newUserSettingsLookup(ctx, api)
+
+	var timesheets []rollupTimesheet
+
+	for page := 1; ; page++ {
+		pageTimesheets, pageSupplemental, more, err := api.ListTimesheets(ctx, qbtime.ListTimesheetsParams{
+			UserIds:          strings.Join(userIds, ","),
+			JobcodeIds:       strings.Join(filterIds(req.Filter, "jobcodes"), ","),
+			StartDate:        windowStart.Format("2006-01-02"),
+			OnTheClock:       "both",
+			Page:             page,
+			SupplementalData: "yes",
+		})
+		if err != nil {
+			return nil, qbtime.Supplemental{}, time.Time{}, false, err
+		}
+
+		for id, jobcode := range pageSupplemental.Jobcodes {
+			supplemental.Jobcodes[id] = jobcode
+		}
+
+		for _, timesheet := range pageTimesheets {
+			date, err := time.Parse("2006-01-02", timesheet.Date)
+			if err != nil {
+				return nil, qbtime.Supplemental{}, time.Time{}, false, fmt.Errorf("unable to parse timesheet date: %w", err)
+			}
+
+			// settings are only looked up for users with time in the window
+			userSettings, err := settings.get(timesheet.UserID.String())
+			if err != nil {
+				return nil, qbtime.Supplemental{}, time.Time{}, false, err
+			}
+
+			offset := (int(date.Weekday()) - int(userSettings.Weekday()) + 7) % 7
+
+			timesheets = append(timesheets, rollupTimesheet{
+				UserID:    timesheet.UserID.String(),
+				JobcodeID: relatedID(timesheet.JobcodeID.String()),
+				Date:      date,
+				WeekStart: date.AddDate(0, 0, -offset),
+				Seconds:   timesheet.Duration,
+			})
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	return timesheets, supplemental, windowStart, moreUsers, nil
+}
+
+// dailyHoursData rolls timesheets up per user, jobcode and date, each page covers a page of users
+func dailyHoursData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	timesheets, supplemental, windowStart, more, err := rollupTimesheets(ctx, api, req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	rollups := map[string]*dailyHoursItem{}
+
+	for _, timesheet := range timesheets {
+		if timesheet.Date.Before(windowStart) {
+			continue
+		}
+
+		date := timesheet.Date.Format("2006-01-02")
+		id := fmt.Sprintf("%s_%s_%s", timesheet.UserID, timesheet.JobcodeID, date)
+
+		rollup, ok := rollups[id]
+		if !ok {
+			rollup = &dailyHoursItem{
+				Id:        id,
+				Name:      fmt.Sprintf("%s %s %s", supplemental.Users[timesheet.UserID].Name, supplemental.Jobcodes[timesheet.JobcodeID].Name, date),
+				UserID:    timesheet.UserID,
+				JobcodeID: timesheet.JobcodeID,
+				Date:      date,
+			}
+			rollups[id] = rollup
+		}
+		rollup.Hours += float64(timesheet.Seconds) / 3600
+		rollup.Timesheets++
+	}
+
+	return sortedRollups(rollups), more, nil
+}
+
+// weeklyHoursData rolls timesheets up per user and week, weeks start on each user's configured day and
+// each page covers a page of users
+func weeklyHoursData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	timesheets, supplemental, windowStart, more, err := rollupTimesheets(ctx, api, req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	rollups := map[string]*weeklyHoursItem{}
+
+	for _, timesheet := range timesheets {
+		// weeks that started before the window are only partially covered
+		if timesheet.WeekStart.Before(windowStart) {
+			continue
+		}
+
+		weekStart := timesheet.WeekStart.Format("2006-01-02")
+		id := fmt.Sprintf("%s_%s", timesheet.UserID, weekStart)
+
+		rollup, ok := rollups[id]
+		if !ok {
+			rollup = &weeklyHoursItem{
+				Id:        id,
+				Name:      fmt.Sprintf("%s week of %s", supplemental.Users[timesheet.UserID].Name, weekStart),
+				UserID:    timesheet.UserID,
+				WeekStart: weekStart,
+				WeekEnd:   timesheet.WeekStart.AddDate(0, 0, 6).Format("2006-01-02"),
+			}
+			rollups[id] = rollup
+		}
+		rollup.Hours += float64(timesheet.Seconds) / 3600
+		rollup.Timesheets++
+	}
+
+	return sortedRollups(rollups), more, nil
+}
+
+// sortedRollups returns rollups ordered by id so every sync emits them in the same order
+func sortedRollups[T any](rollups map[string]*T) []any {
+	ids := make([]string, 0, len(rollups))
+	for id := range rollups {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	items := make([]any, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, *rollups[id])
+	}
+	return items
+}
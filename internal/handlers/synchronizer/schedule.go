@@ -0,0 +1,149 @@
+package synchronizer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
+)
+
+type scheduleCalendarItem struct {
+	Id         string `json:"id"`
+	TimeID     string `json:"timeId"`
+	Name       string `json:"name"`
+	SyncAction string `json:"__syncAction,omitempty"`
+}
+
+type scheduleEventItem struct {
+	Id                 string   `json:"id"`
+	TimeID             string   `json:"timeId"`
+	ScheduleCalendarID string   `json:"schedule_calendar_id"`
+	Title              string   `json:"title"`
+	Start              string   `json:"start,omitempty"`
+	End                string   `json:"end,omitempty"`
+	AllDay             bool     `json:"all_day"`
+	AssignedUserIDs    []string `json:"assigned_user_ids"`
+	JobcodeID          string   `json:"jobcode_id"`
+	Color              string   `json:"color"`
+	Notes              string   `json:"notes"`
+	State              string   `json:"state"`
+	SyncAction         string   `json:"__syncAction,omitempty"`
+}
+
+func scheduleCalendarData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	calendars, more, err := api.ListScheduleCalendars(ctx, qbtime.ListScheduleCalendarsParams{
+		Page:             req.Page,
+		SupplementalData: "no",
+		ModifiedSince:    req.ModifiedSince,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	var items []any
+
+	for _, calendar := range calendars {
+		item := scheduleCalendarItem{
+			Id:     calendar.Id.String(),
+			TimeID: calendar.Id.String(),
+			Name:   calendar.Name,
+		}
+		if req.Sync == "delta" {
+			item.SyncAction = "SET"
+		}
+		items = append(items, item)
+	}
+
+	return items, more, nil
+}
+
+// scheduleEventData syncs events from every calendar, events are synced from the same start date as timesheets
+func scheduleEventData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	startDate, err := timesheetStart(req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// events can only be listed by calendar, the calendars are listed with the first page and carried to the rest
+	calendarIds, ok := req.State["scheduleCalendarIds"]
+	if !ok {
+		var ids []string
+		for page := 1; ; page++ {
+			calendars, more, err := api.ListScheduleCalendars(ctx, qbtime.ListScheduleCalendarsParams{
+				Page:             page,
+				SupplementalData: "no",
+			})
+			if err != nil {
+				return nil, false, err
+			}
+			for _, calendar := range calendars {
+				ids = append(ids, calendar.Id.String())
+			}
+			if !more {
+				break
+			}
+		}
+		calendarIds = strings.Join(ids, ",")
+		if req.State != nil {
+			req.State["scheduleCalendarIds"] = calendarIds
+		}
+	}
+
+	if calendarIds == "" {
+		return nil, false, nil
+	}
+
+	active := activeParam(req)
+
+	events, more, err := api.ListScheduleEvents(ctx, qbtime.ListScheduleEventsParams{
+		ScheduleCalendarIds: calendarIds,
+		Start:               req.startOfDay(startDate),
+		Active:              active,
+		Page:                req.Page,
+		SupplementalData:    "no",
+		ModifiedSince:       req.ModifiedSince,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	var items []any
+
+	for _, event := range events {
+		if item, ok := removedItem(req, event.Active, event.Id.String()); ok {
+			items = append(items, item)
+			continue
+		}
+
+		assignedUserIDs := []string{}
+		for _, id := range event.AssignedUserIDs {
+			assignedUserIDs = append(assignedUserIDs, id.String())
+		}
+
+		state := "Published"
+		if event.Draft {
+			state = "Draft"
+		}
+
+		item := scheduleEventItem{
+			Id:                 event.Id.String(),
+			TimeID:             event.Id.String(),
+			ScheduleCalendarID: event.ScheduleCalendarID.String(),
+			Title:              event.Title,
+			Start:              event.Start,
+			End:                event.End,
+			AllDay:             event.AllDay,
+			AssignedUserIDs:    assignedUserIDs,
+			JobcodeID:          relatedID(event.JobcodeID.String()),
+			Color:              event.Color,
+			Notes:              event.Notes,
+			State:              state,
+		}
+		if req.Sync == "delta" {
+			item.SyncAction = "SET"
+		}
+		items = append(items, item)
+	}
+
+	return items, more, nil
+}
@@ -2,8 +2,10 @@ package synchronizer
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
 	"github.com/tommyhedley/fiberytsheets/internal/utils"
 )
 
@@ -25,125 +27,1481 @@ type Relation struct {
 	TargetFieldID string `json:"targetFieldId"`
 }
 
+type FieldOption struct {
+	Name string `json:"name"`
+}
+
+var timeOffStatuses = []FieldOption{
+	{Name: "pending"},
+	{Name: "approved"},
+	{Name: "denied"},
+	{Name: "canceled"},
+}
+
 type Field struct {
-	Ignore      bool      `json:"ignore,omitempty"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	ReadOnly    bool      `json:"readonly,omitempty"`
-	Type        string    `json:"type,omitempty"`
-	SubType     string    `json:"subType,omitempty"`
-	Relation    *Relation `json:"relation,omitempty"`
+	Ignore      bool          `json:"ignore,omitempty"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	ReadOnly    bool          `json:"readonly,omitempty"`
+	Type        string        `json:"type,omitempty"`
+	SubType     string        `json:"subType,omitempty"`
+	Options     []FieldOption `json:"options,omitempty"`
+	Relation    *Relation     `json:"relation,omitempty"`
 }
 
-func Schema(w http.ResponseWriter, r *http.Request) {
-	type parameters struct {
-		Types   []string   `json:"types"`
-		Filter  SyncFilter `json:"filter"`
-		Account struct {
-			Token string `json:"token"`
-		} `json:"account"`
-	}
+func Schema(client *qbtime.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type parameters struct {
+			Types   []string       `json:"types"`
+			Filter  map[string]any `json:"filter"`
+			Account struct {
+				AccessToken string `json:"access_token"`
+			} `json:"account"`
+		}
 
-	decoder := json.NewDecoder(r.Body)
-	params := parameters{}
-	err := decoder.Decode(&params)
-	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Couldn't decode parameters")
-		return
-	}
+		decoder := json.NewDecoder(r.Body)
+		params := parameters{}
+		err := decoder.Decode(&params)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, "Couldn't decode parameters")
+			return
+		}
 
-	user := map[string]Field{
-		"id": {
-			Name: "Id",
-			Type: "id",
-		},
-		"timeId": {
-			Name:     "Time ID",
-			Type:     "text",
-			ReadOnly: true,
-		},
-		"display_name": {
-			Name:    "Name",
-			Type:    "text",
-			SubType: "title",
-		},
-		"first_name": {
-			Name: "First Name",
-			Type: "text",
-		},
-		"last_name": {
-			Name: "Last Name",
-			Type: "text",
-		},
-		"active": {
-			Name:     "Active",
-			SubType:  "boolean",
-			ReadOnly: false,
-		},
-		"email": {
-			Name:    "Email",
-			SubType: "email",
-		},
-		"last_active": {
-			Name: "Last Active",
-			Type: "date",
-		},
-		"__syncAction": {
-			Type: "text",
-			Name: "Sync Action",
-		},
-		"group_id": {
-			Name: "Group ID",
-			Type: "text",
-			Relation: &Relation{
-				Cardinality:   "many-to-many",
-				Name:          "Group",
-				TargetName:    "Users",
-				TargetType:    "group",
-				TargetFieldID: "id",
-			},
-		},
-	}
+		user := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"timeId": {
+				Name:     "Time ID",
+				Type:     "text",
+				ReadOnly: true,
+			},
+			"display_name": {
+				Name:    "Name",
+				Type:    "text",
+				SubType: "title",
+			},
+			"first_name": {
+				Name: "First Name",
+				Type: "text",
+			},
+			"last_name": {
+				Name: "Last Name",
+				Type: "text",
+			},
+			"active": {
+				Name:     "Active",
+				SubType:  "boolean",
+				ReadOnly: false,
+			},
+			"email": {
+				Name:    "Email",
+				SubType: "email",
+			},
+			"last_active": {
+				Name: "Last Active",
+				Type: "date",
+			},
+			"__syncAction": {
+				Type: "text",
+				Name: "Sync Action",
+			},
+			"group_id": {
+				Name: "Group ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-many",
+					Name:          "Group",
+					TargetName:    "Users",
+					TargetType:    "group",
+					TargetFieldID: "id",
+				},
+			},
+			"group_name": {
+				Name:     "Group Name",
+				Type:     "text",
+				ReadOnly: true,
+			},
+			"submitted_to": {
+				Name:        "Submitted To",
+				Description: "Last date the user has submitted time for",
+				Type:        "date",
+			},
+			"approved_to": {
+				Name:        "Approved To",
+				Description: "Last date the user's time has been approved for",
+				Type:        "date",
+			},
+		}
 
-	group := map[string]Field{
-		"id": {
-			Name: "Id",
-			Type: "id",
-		},
-		"timeId": {
-			Name:     "Time ID",
-			Type:     "text",
-			ReadOnly: true,
-		},
-		"name": {
-			Name: "Name",
-			Type: "text",
-		},
-		"active": {
-			Name:     "Active",
-			SubType:  "boolean",
-			ReadOnly: false,
-		},
-		"__syncAction": {
-			Type: "text",
-			Name: "Sync Action",
-		},
-	}
+		group := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"timeId": {
+				Name:     "Time ID",
+				Type:     "text",
+				ReadOnly: true,
+			},
+			"name": {
+				Name: "Name",
+				Type: "text",
+			},
+			"active": {
+				Name:     "Active",
+				SubType:  "boolean",
+				ReadOnly: false,
+			},
+			"__syncAction": {
+				Type: "text",
+				Name: "Sync Action",
+			},
+		}
 
-	allType := map[string]map[string]Field{
-		"user":  user,
-		"group": group,
-	}
+		timesheet := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"timeId": {
+				Name:     "Time ID",
+				Type:     "text",
+				ReadOnly: true,
+			},
+			"user_id": {
+				Name: "User ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "User",
+					TargetName:    "Timesheets",
+					TargetType:    "user",
+					TargetFieldID: "id",
+				},
+			},
+			"jobcode_id": {
+				Name: "Jobcode ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "Jobcode",
+					TargetName:    "Timesheets",
+					TargetType:    "jobcode",
+					TargetFieldID: "id",
+				},
+			},
+			"user_name": {
+				Name:     "User Name",
+				Type:     "text",
+				ReadOnly: true,
+			},
+			"jobcode_name": {
+				Name:     "Jobcode Name",
+				Type:     "text",
+				ReadOnly: true,
+			},
+			"start": {
+				Name: "Start",
+				Type: "date",
+			},
+			"end": {
+				Name: "End",
+				Type: "date",
+			},
+			"date": {
+				Name: "Date",
+				Type: "date",
+			},
+			"duration": {
+				Name:        "Duration",
+				Description: "Duration in hours",
+				Type:        "number",
+			},
+			"type": {
+				Name: "Type",
+				Type: "text",
+			},
+			"on_the_clock": {
+				Name:    "On The Clock",
+				SubType: "boolean",
+			},
+			"notes": {
+				Name: "Notes",
+				Type: "text",
+			},
+			"files": {
+				Name:    "Files",
+				Type:    "array[text]",
+				SubType: "file",
+			},
+			"submitted": {
+				Name:     "Submitted",
+				SubType:  "boolean",
+				ReadOnly: true,
+			},
+			"approved": {
+				Name:     "Approved",
+				SubType:  "boolean",
+				ReadOnly: true,
+			},
+			"approval_state": {
+				Name:    "Approval State",
+				Type:    "text",
+				SubType: "single-select",
+				Options: []FieldOption{
+					{Name: "Open"},
+					{Name: "Submitted"},
+					{Name: "Approved"},
+				},
+			},
+			"__syncAction": {
+				Type: "text",
+				Name: "Sync Action",
+			},
+		}
+
+		jobcode := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"timeId": {
+				Name:     "Time ID",
+				Type:     "text",
+				ReadOnly: true,
+			},
+			"name": {
+				Name:    "Name",
+				Type:    "text",
+				SubType: "title",
+			},
+			"short_code": {
+				Name: "Short Code",
+				Type: "text",
+			},
+			"type": {
+				Name: "Type",
+				Type: "text",
+			},
+			"parent_id": {
+				Name: "Parent ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "Parent",
+					TargetName:    "Children",
+					TargetType:    "jobcode",
+					TargetFieldID: "id",
+				},
+			},
+			"has_children": {
+				Name:    "Has Children",
+				SubType: "boolean",
+			},
+			"billable": {
+				Name:    "Billable",
+				SubType: "boolean",
+			},
+			"billable_rate": {
+				Name: "Billable Rate",
+				Type: "number",
+			},
+			"assigned_to_all": {
+				Name:    "Assigned To All",
+				SubType: "boolean",
+			},
+			"active": {
+				Name:     "Active",
+				SubType:  "boolean",
+				ReadOnly: false,
+			},
+			"__syncAction": {
+				Type: "text",
+				Name: "Sync Action",
+			},
+		}
+
+		customfield := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"timeId": {
+				Name:     "Time ID",
+				Type:     "text",
+				ReadOnly: true,
+			},
+			"name": {
+				Name:    "Name",
+				Type:    "text",
+				SubType: "title",
+			},
+			"short_code": {
+				Name: "Short Code",
+				Type: "text",
+			},
+			"type": {
+				Name: "Type",
+				Type: "text",
+			},
+			"applies_to": {
+				Name: "Applies To",
+				Type: "text",
+			},
+			"required": {
+				Name:    "Required",
+				SubType: "boolean",
+			},
+			"active": {
+				Name:     "Active",
+				SubType:  "boolean",
+				ReadOnly: false,
+			},
+			"__syncAction": {
+				Type: "text",
+				Name: "Sync Action",
+			},
+		}
+
+		customfielditem := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"timeId": {
+				Name:     "Time ID",
+				Type:     "text",
+				ReadOnly: true,
+			},
+			"customfield_id": {
+				Name: "Custom Field ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "Custom Field",
+					TargetName:    "Items",
+					TargetType:    "customfield",
+					TargetFieldID: "id",
+				},
+			},
+			"name": {
+				Name:    "Name",
+				Type:    "text",
+				SubType: "title",
+			},
+			"short_code": {
+				Name: "Short Code",
+				Type: "text",
+			},
+			"active": {
+				Name:     "Active",
+				SubType:  "boolean",
+				ReadOnly: false,
+			},
+			"__syncAction": {
+				Type: "text",
+				Name: "Sync Action",
+			},
+		}
+
+		scheduleCalendar := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"timeId": {
+				Name:     "Time ID",
+				Type:     "text",
+				ReadOnly: true,
+			},
+			"name": {
+				Name:    "Name",
+				Type:    "text",
+				SubType: "title",
+			},
+			"__syncAction": {
+				Type: "text",
+				Name: "Sync Action",
+			},
+		}
+
+		scheduleEvent := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"timeId": {
+				Name:     "Time ID",
+				Type:     "text",
+				ReadOnly: true,
+			},
+			"title": {
+				Name:    "Title",
+				Type:    "text",
+				SubType: "title",
+			},
+			"schedule_calendar_id": {
+				Name: "Schedule Calendar ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "Schedule Calendar",
+					TargetName:    "Schedule Events",
+					TargetType:    "schedule_calendar",
+					TargetFieldID: "id",
+				},
+			},
+			"assigned_user_ids": {
+				Name: "Assigned User IDs",
+				Type: "array[text]",
+				Relation: &Relation{
+					Cardinality:   "many-to-many",
+					Name:          "Assigned Users",
+					TargetName:    "Schedule Events",
+					TargetType:    "user",
+					TargetFieldID: "id",
+				},
+			},
+			"jobcode_id": {
+				Name: "Jobcode ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "Jobcode",
+					TargetName:    "Schedule Events",
+					TargetType:    "jobcode",
+					TargetFieldID: "id",
+				},
+			},
+			"start": {
+				Name: "Start",
+				Type: "date",
+			},
+			"end": {
+				Name: "End",
+				Type: "date",
+			},
+			"all_day": {
+				Name:    "All Day",
+				SubType: "boolean",
+			},
+			"color": {
+				Name: "Color",
+				Type: "text",
+			},
+			"notes": {
+				Name: "Notes",
+				Type: "text",
+			},
+			"state": {
+				Name:    "State",
+				Type:    "text",
+				SubType: "single-select",
+				Options: []FieldOption{
+					{Name: "Draft"},
+					{Name: "Published"},
+				},
+			},
+			"__syncAction": {
+				Type: "text",
+				Name: "Sync Action",
+			},
+		}
+
+		timeOffRequest := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"timeId": {
+				Name:     "Time ID",
+				Type:     "text",
+				SubType:  "title",
+				ReadOnly: true,
+			},
+			"user_id": {
+				Name: "User ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "User",
+					TargetName:    "Time Off Requests",
+					TargetType:    "user",
+					TargetFieldID: "id",
+				},
+			},
+			"status": {
+				Name:    "Status",
+				Type:    "text",
+				SubType: "single-select",
+				Options: timeOffStatuses,
+			},
+			"__syncAction": {
+				Type: "text",
+				Name: "Sync Action",
+			},
+		}
+
+		timeOffRequestEntry := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"timeId": {
+				Name:     "Time ID",
+				Type:     "text",
+				SubType:  "title",
+				ReadOnly: true,
+			},
+			"time_off_request_id": {
+				Name: "Time Off Request ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "Time Off Request",
+					TargetName:    "Entries",
+					TargetType:    "time_off_request",
+					TargetFieldID: "id",
+				},
+			},
+			"user_id": {
+				Name: "User ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "User",
+					TargetName:    "Time Off Request Entries",
+					TargetType:    "user",
+					TargetFieldID: "id",
+				},
+			},
+			"jobcode_id": {
+				Name: "Jobcode ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "PTO Code",
+					TargetName:    "Time Off Request Entries",
+					TargetType:    "jobcode",
+					TargetFieldID: "id",
+				},
+			},
+			"approver_user_id": {
+				Name: "Approver User ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "Approver",
+					TargetName:    "Approved Time Off",
+					TargetType:    "user",
+					TargetFieldID: "id",
+				},
+			},
+			"status": {
+				Name:    "Status",
+				Type:    "text",
+				SubType: "single-select",
+				Options: timeOffStatuses,
+			},
+			"date": {
+				Name: "Date",
+				Type: "date",
+			},
+			"start_time": {
+				Name: "Start Time",
+				Type: "date",
+			},
+			"end_time": {
+				Name: "End Time",
+				Type: "date",
+			},
+			"duration": {
+				Name:        "Duration",
+				Description: "Duration in hours",
+				Type:        "number",
+			},
+			"entry_method": {
+				Name: "Entry Method",
+				Type: "text",
+			},
+			"__syncAction": {
+				Type: "text",
+				Name: "Sync Action",
+			},
+		}
+
+		project := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"timeId": {
+				Name:     "Time ID",
+				Type:     "text",
+				ReadOnly: true,
+			},
+			"name": {
+				Name:    "Name",
+				Type:    "text",
+				SubType: "title",
+			},
+			"jobcode_id": {
+				Name: "Jobcode ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "Jobcode",
+					TargetName:    "Projects",
+					TargetType:    "jobcode",
+					TargetFieldID: "id",
+				},
+			},
+			"parent_jobcode_id": {
+				Name: "Parent Jobcode ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "Parent Jobcode",
+					TargetName:    "Child Projects",
+					TargetType:    "jobcode",
+					TargetFieldID: "id",
+				},
+			},
+			"status": {
+				Name:    "Status",
+				Type:    "text",
+				SubType: "single-select",
+				Options: []FieldOption{
+					{Name: "not_started"},
+					{Name: "in_progress"},
+					{Name: "complete"},
+				},
+			},
+			"description": {
+				Name:    "Description",
+				Type:    "text",
+				SubType: "md",
+			},
+			"start_date": {
+				Name: "Start Date",
+				Type: "date",
+			},
+			"due_date": {
+				Name: "Due Date",
+				Type: "date",
+			},
+			"completed_date": {
+				Name: "Completed Date",
+				Type: "date",
+			},
+			"__syncAction": {
+				Type: "text",
+				Name: "Sync Action",
+			},
+		}
+
+		projectNote := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"timeId": {
+				Name:     "Time ID",
+				Type:     "text",
+				SubType:  "title",
+				ReadOnly: true,
+			},
+			"project_id": {
+				Name: "Project ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "Project",
+					TargetName:    "Notes",
+					TargetType:    "project",
+					TargetFieldID: "id",
+				},
+			},
+			"user_id": {
+				Name: "User ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "User",
+					TargetName:    "Project Notes",
+					TargetType:    "user",
+					TargetFieldID: "id",
+				},
+			},
+			"note": {
+				Name:    "Note",
+				Type:    "text",
+				SubType: "md",
+			},
+			"files": {
+				Name:    "Files",
+				Type:    "array[text]",
+				SubType: "file",
+			},
+			"created": {
+				Name: "Created",
+				Type: "date",
+			},
+			"__syncAction": {
+				Type: "text",
+				Name: "Sync Action",
+			},
+		}
+
+		projectActivity := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"timeId": {
+				Name:     "Time ID",
+				Type:     "text",
+				ReadOnly: true,
+			},
+			"activity_type": {
+				Name:    "Activity Type",
+				Type:    "text",
+				SubType: "title",
+			},
+			"project_id": {
+				Name: "Project ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "Project",
+					TargetName:    "Activities",
+					TargetType:    "project",
+					TargetFieldID: "id",
+				},
+			},
+			"user_id": {
+				Name: "User ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "User",
+					TargetName:    "Project Activities",
+					TargetType:    "user",
+					TargetFieldID: "id",
+				},
+			},
+			"created": {
+				Name: "Created",
+				Type: "date",
+			},
+			"__syncAction": {
+				Type: "text",
+				Name: "Sync Action",
+			},
+		}
+
+		location := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"timeId": {
+				Name:     "Time ID",
+				Type:     "text",
+				ReadOnly: true,
+			},
+			"label": {
+				Name:    "Label",
+				Type:    "text",
+				SubType: "title",
+			},
+			"address": {
+				Name: "Address",
+				Type: "text",
+			},
+			"city": {
+				Name: "City",
+				Type: "text",
+			},
+			"state": {
+				Name: "State",
+				Type: "text",
+			},
+			"zip": {
+				Name: "Zip",
+				Type: "text",
+			},
+			"country": {
+				Name: "Country",
+				Type: "text",
+			},
+			"location": {
+				Name:    "Location",
+				Type:    "text",
+				SubType: "location",
+			},
+			"geofence_radius": {
+				Name:        "Geofence Radius",
+				Description: "Geofence radius in meters, 0 when geofencing is disabled",
+				Type:        "number",
+			},
+			"__syncAction": {
+				Type: "text",
+				Name: "Sync Action",
+			},
+		}
 
-	returnType := map[string]map[string]Field{}
+		geolocation := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"timeId": {
+				Name:     "Time ID",
+				Type:     "text",
+				SubType:  "title",
+				ReadOnly: true,
+			},
+			"user_id": {
+				Name: "User ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "User",
+					TargetName:    "Geolocations",
+					TargetType:    "user",
+					TargetFieldID: "id",
+				},
+			},
+			"location": {
+				Name:    "Location",
+				Type:    "text",
+				SubType: "location",
+			},
+			"accuracy": {
+				Name:        "Accuracy",
+				Description: "Accuracy in meters",
+				Type:        "number",
+			},
+			"source": {
+				Name: "Source",
+				Type: "text",
+			},
+			"created": {
+				Name: "Created",
+				Type: "date",
+			},
+			"__syncAction": {
+				Type: "text",
+				Name: "Sync Action",
+			},
+		}
 
-	for name, fields := range allType {
-		for _, t := range params.Types {
-			if name == t {
-				returnType[name] = fields
+		jobcodeAssignment := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"timeId": {
+				Name:     "Time ID",
+				Type:     "text",
+				SubType:  "title",
+				ReadOnly: true,
+			},
+			"user_id": {
+				Name: "User ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "User",
+					TargetName:    "Jobcode Assignments",
+					TargetType:    "user",
+					TargetFieldID: "id",
+				},
+			},
+			"jobcode_id": {
+				Name: "Jobcode ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "Jobcode",
+					TargetName:    "User Assignments",
+					TargetType:    "jobcode",
+					TargetFieldID: "id",
+				},
+			},
+			"created": {
+				Name: "Created",
+				Type: "date",
+			},
+			"__syncAction": {
+				Type: "text",
+				Name: "Sync Action",
+			},
+		}
+
+		file := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"timeId": {
+				Name:     "Time ID",
+				Type:     "text",
+				ReadOnly: true,
+			},
+			"file_name": {
+				Name:    "File Name",
+				Type:    "text",
+				SubType: "title",
+			},
+			"file_description": {
+				Name: "Description",
+				Type: "text",
+			},
+			"uploaded_by_user_id": {
+				Name: "Uploaded By User ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "Uploaded By",
+					TargetName:    "Uploaded Files",
+					TargetType:    "user",
+					TargetFieldID: "id",
+				},
+			},
+			"size": {
+				Name:        "Size",
+				Description: "Size in bytes",
+				Type:        "number",
+			},
+			"created": {
+				Name: "Created",
+				Type: "date",
+			},
+			"timesheet_ids": {
+				Name: "Timesheet IDs",
+				Type: "array[text]",
+				Relation: &Relation{
+					Cardinality:   "many-to-many",
+					Name:          "Timesheets",
+					TargetName:    "Attachments",
+					TargetType:    "timesheet",
+					TargetFieldID: "id",
+				},
+			},
+			"project_note_ids": {
+				Name: "Project Note IDs",
+				Type: "array[text]",
+				Relation: &Relation{
+					Cardinality:   "many-to-many",
+					Name:          "Project Notes",
+					TargetName:    "Attachments",
+					TargetType:    "project_note",
+					TargetFieldID: "id",
+				},
+			},
+			"file": {
+				Name:    "File",
+				Type:    "array[text]",
+				SubType: "file",
+			},
+			"__syncAction": {
+				Type: "text",
+				Name: "Sync Action",
+			},
+		}
+
+		reminder := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"timeId": {
+				Name:     "Time ID",
+				Type:     "text",
+				SubType:  "title",
+				ReadOnly: true,
+			},
+			"user_id": {
+				Name:        "User ID",
+				Description: "Empty for company-wide reminders",
+				Type:        "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "User",
+					TargetName:    "Reminders",
+					TargetType:    "user",
+					TargetFieldID: "id",
+				},
+			},
+			"scope": {
+				Name:    "Scope",
+				Type:    "text",
+				SubType: "single-select",
+				Options: []FieldOption{
+					{Name: "Company"},
+					{Name: "User"},
+				},
+			},
+			"reminder_type": {
+				Name: "Reminder Type",
+				Type: "text",
+			},
+			"due_time": {
+				Name: "Due Time",
+				Type: "text",
+			},
+			"due_days_of_week": {
+				Name: "Due Days Of Week",
+				Type: "text",
+			},
+			"distribution_methods": {
+				Name: "Distribution Methods",
+				Type: "text",
+			},
+			"enabled": {
+				Name:    "Enabled",
+				SubType: "boolean",
+			},
+			"__syncAction": {
+				Type: "text",
+				Name: "Sync Action",
+			},
+		}
+
+		notification := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"timeId": {
+				Name:     "Time ID",
+				Type:     "text",
+				ReadOnly: true,
+			},
+			"message": {
+				Name:    "Message",
+				Type:    "text",
+				SubType: "title",
+			},
+			"user_id": {
+				Name: "User ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "User",
+					TargetName:    "Notifications",
+					TargetType:    "user",
+					TargetFieldID: "id",
+				},
+			},
+			"method": {
+				Name: "Method",
+				Type: "text",
+			},
+			"delivery_time": {
+				Name: "Delivery Time",
+				Type: "date",
+			},
+		}
+
+		currentTotals := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"name": {
+				Name:    "Name",
+				Type:    "text",
+				SubType: "title",
+			},
+			"user_id": {
+				Name: "User ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "one-to-one",
+					Name:          "User",
+					TargetName:    "Current Totals",
+					TargetType:    "user",
+					TargetFieldID: "id",
+				},
+			},
+			"group_id": {
+				Name: "Group ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "Group",
+					TargetName:    "Current Totals",
+					TargetType:    "group",
+					TargetFieldID: "id",
+				},
+			},
+			"jobcode_id": {
+				Name: "Jobcode ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "Current Jobcode",
+					TargetName:    "Working Now",
+					TargetType:    "jobcode",
+					TargetFieldID: "id",
+				},
+			},
+			"timesheet_id": {
+				Name: "Timesheet ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "one-to-one",
+					Name:          "Current Timesheet",
+					TargetName:    "Current Totals",
+					TargetType:    "timesheet",
+					TargetFieldID: "id",
+				},
+			},
+			"on_the_clock": {
+				Name:    "On The Clock",
+				SubType: "boolean",
+			},
+			"shift_seconds": {
+				Name:        "Shift Seconds",
+				Description: "Seconds worked in the current shift",
+				Type:        "number",
+			},
+			"day_seconds": {
+				Name:        "Day Seconds",
+				Description: "Seconds worked today",
+				Type:        "number",
+			},
+		}
+
+		payrollReport := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"name": {
+				Name:    "Name",
+				Type:    "text",
+				SubType: "title",
+			},
+			"user_id": {
+				Name: "User ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "User",
+					TargetName:    "Payroll Reports",
+					TargetType:    "user",
+					TargetFieldID: "id",
+				},
+			},
+			"start_date": {
+				Name: "Start Date",
+				Type: "date",
+			},
+			"end_date": {
+				Name: "End Date",
+				Type: "date",
+			},
+			"regular_hours": {
+				Name: "Regular Hours",
+				Type: "number",
+			},
+			"overtime_hours": {
+				Name: "Overtime Hours",
+				Type: "number",
+			},
+			"double_time_hours": {
+				Name: "Double Time Hours",
+				Type: "number",
+			},
+			"pto_hours": {
+				Name: "PTO Hours",
+				Type: "number",
+			},
+			"total_hours": {
+				Name:        "Total Hours",
+				Description: "Worked and PTO hours",
+				Type:        "number",
+			},
+		}
+
+		projectEstimate := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"name": {
+				Name:    "Name",
+				Type:    "text",
+				SubType: "title",
+			},
+			"project_id": {
+				Name: "Project ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "one-to-one",
+					Name:          "Project",
+					TargetName:    "Estimate",
+					TargetType:    "project",
+					TargetFieldID: "id",
+				},
+			},
+			"jobcode_id": {
+				Name: "Jobcode ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "Jobcode",
+					TargetName:    "Project Estimates",
+					TargetType:    "jobcode",
+					TargetFieldID: "id",
+				},
+			},
+			"estimated_hours": {
+				Name: "Estimated Hours",
+				Type: "number",
+			},
+			"actual_hours": {
+				Name: "Actual Hours",
+				Type: "number",
+			},
+			"variance_hours": {
+				Name:        "Variance Hours",
+				Description: "Actual hours minus estimated hours, positive when over budget",
+				Type:        "number",
+			},
+			"over_budget": {
+				Name:    "Over Budget",
+				SubType: "boolean",
+			},
+		}
+
+		dailyHours := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"name": {
+				Name:    "Name",
+				Type:    "text",
+				SubType: "title",
+			},
+			"user_id": {
+				Name: "User ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "User",
+					TargetName:    "Daily Hours",
+					TargetType:    "user",
+					TargetFieldID: "id",
+				},
+			},
+			"jobcode_id": {
+				Name: "Jobcode ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "Jobcode",
+					TargetName:    "Daily Hours",
+					TargetType:    "jobcode",
+					TargetFieldID: "id",
+				},
+			},
+			"date": {
+				Name: "Date",
+				Type: "date",
+			},
+			"hours": {
+				Name: "Hours",
+				Type: "number",
+			},
+			"timesheets": {
+				Name:        "Timesheets",
+				Description: "Number of timesheets in the rollup",
+				Type:        "number",
+			},
+		}
+
+		weeklyHours := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"name": {
+				Name:    "Name",
+				Type:    "text",
+				SubType: "title",
+			},
+			"user_id": {
+				Name: "User ID",
+				Type: "text",
+				Relation: &Relation{
+					Cardinality:   "many-to-one",
+					Name:          "User",
+					TargetName:    "Weekly Hours",
+					TargetType:    "user",
+					TargetFieldID: "id",
+				},
+			},
+			"week_start": {
+				Name: "Week Start",
+				Type: "date",
+			},
+			"week_end": {
+				Name: "Week End",
+				Type: "date",
+			},
+			"hours": {
+				Name: "Hours",
+				Type: "number",
+			},
+			"timesheets": {
+				Name:        "Timesheets",
+				Description: "Number of timesheets in the rollup",
+				Type:        "number",
+			},
+		}
+
+		effectiveSettings := map[string]Field{
+			"id": {
+				Name: "Id",
+				Type: "id",
+			},
+			"name": {
+				Name:    "Name",
+				Type:    "text",
+				SubType: "title",
+			},
+			"user_id": {
+				Name:        "User ID",
+				Description: "Empty for the company settings",
+				Type:        "text",
+				Relation: &Relation{
+					Cardinality:   "one-to-one",
+					Name:          "User",
+					TargetName:    "Effective Settings",
+					TargetType:    "user",
+					TargetFieldID: "id",
+				},
+			},
+			"time_zone": {
+				Name: "Time Zone",
+				Type: "text",
+			},
+			"week_start": {
+				Name:    "Week Start",
+				Type:    "text",
+				SubType: "single-select",
+				Options: []FieldOption{
+					{Name: "Sunday"},
+					{Name: "Monday"},
+					{Name: "Tuesday"},
+					{Name: "Wednesday"},
+					{Name: "Thursday"},
+					{Name: "Friday"},
+					{Name: "Saturday"},
+				},
+			},
+			"calculate_overtime": {
+				Name:    "Calculate Overtime",
+				SubType: "boolean",
+			},
+			"daily_regular_hours": {
+				Name:        "Daily Regular Hours",
+				Description: "Hours in a day before overtime",
+				Type:        "number",
+			},
+			"daily_overtime_hours": {
+				Name:        "Daily Overtime Hours",
+				Description: "Hours in a day before double time",
+				Type:        "number",
+			},
+			"weekly_regular_hours": {
+				Name:        "Weekly Regular Hours",
+				Description: "Hours in a week before overtime",
+				Type:        "number",
+			},
+			"require_notes": {
+				Name:    "Require Notes",
+				SubType: "boolean",
+			},
+			"require_jobcode": {
+				Name:    "Require Jobcode",
+				SubType: "boolean",
+			},
+			"required_customfields": {
+				Name: "Required Custom Fields",
+				Type: "array[text]",
+			},
+		}
+
+		allType := map[string]map[string]Field{
+			"user":                   user,
+			"group":                  group,
+			"timesheet":              timesheet,
+			"jobcode":                jobcode,
+			"customfield":            customfield,
+			"customfielditem":        customfielditem,
+			"schedule_calendar":      scheduleCalendar,
+			"schedule_event":         scheduleEvent,
+			"time_off_request":       timeOffRequest,
+			"time_off_request_entry": timeOffRequestEntry,
+			"project":                project,
+			"project_note":           projectNote,
+			"project_activity":       projectActivity,
+			"location":               location,
+			"geolocation":            geolocation,
+			"jobcode_assignment":     jobcodeAssignment,
+			"file":                   file,
+			"reminder":               reminder,
+			"notification":           notification,
+			"current_totals":         currentTotals,
+			"payroll_report":         payrollReport,
+			"project_estimate":       projectEstimate,
+			"daily_hours":            dailyHours,
+			"weekly_hours":           weeklyHours,
+			"effective_settings":     effectiveSettings,
+		}
+
+		// custom fields are only looked up when a type that carries them is synced, accounts without
+		// the custom fields add-on can still sync the other types
+		if hasCustomFieldTypes(params.Types) {
+			ctx, cancel := client.WithBudget(r.Context())
+			defer cancel()
+
+			customFields, err := customFieldSchema(ctx, client.WithToken(params.Account.AccessToken), relateCustomFieldItems(params.Types))
+			if err != nil {
+				if qbtime.IsTemporary(err) {
+					utils.RespondWithTryLater(w, http.StatusServiceUnavailable, fmt.Sprintf("temporary error building schema: %v", err))
+					return
+				}
+				utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("error building schema: %v", err))
+				return
+			}
+
+			for name, fields := range customFields {
+				for id, field := range fields {
+					allType[name][id] = field
+				}
 			}
 		}
-	}
 
-	utils.RespondWithJSON(w, http.StatusOK, returnType)
+		returnType := map[string]map[string]Field{}
+
+		for name, fields := range allType {
+			for _, t := range params.Types {
+				if name == t {
+					returnType[name] = fields
+				}
+			}
+		}
+
+		utils.RespondWithJSON(w, http.StatusOK, returnType)
+	}
 }
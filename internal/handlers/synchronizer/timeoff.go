@@ -0,0 +1,115 @@
+package synchronizer
+
+import (
+	"context"
+
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
+)
+
+type timeOffRequestItem struct {
+	Id         string `json:"id"`
+	TimeID     string `json:"timeId"`
+	UserID     string `json:"user_id"`
+	Status     string `json:"status"`
+	SyncAction string `json:"__syncAction,omitempty"`
+}
+
+type timeOffRequestEntryItem struct {
+	Id               string  `json:"id"`
+	TimeID           string  `json:"timeId"`
+	TimeOffRequestID string  `json:"time_off_request_id"`
+	UserID           string  `json:"user_id"`
+	JobcodeID        string  `json:"jobcode_id"`
+	ApproverUserID   string  `json:"approver_user_id"`
+	Status           string  `json:"status"`
+	Date             string  `json:"date"`
+	StartTime        string  `json:"start_time,omitempty"`
+	EndTime          string  `json:"end_time,omitempty"`
+	Duration         float64 `json:"duration"`
+	EntryMethod      string  `json:"entry_method"`
+	SyncAction       string  `json:"__syncAction,omitempty"`
+}
+
+func timeOffRequestData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	requests, more, err := api.ListTimeOffRequests(ctx, qbtime.ListTimeOffRequestsParams{
+		Page:             req.Page,
+		SupplementalData: "no",
+		ModifiedSince:    req.ModifiedSince,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	var items []any
+
+	for _, request := range requests {
+		if !request.Active {
+			if req.Sync == "delta" {
+				items = append(items, removeItem(request.Id.String()))
+			}
+			continue
+		}
+
+		item := timeOffRequestItem{
+			Id:     request.Id.String(),
+			TimeID: request.Id.String(),
+			UserID: request.UserID.String(),
+			Status: request.Status,
+		}
+		if req.Sync == "delta" {
+			item.SyncAction = "SET"
+		}
+		items = append(items, item)
+	}
+
+	return items, more, nil
+}
+
+func timeOffRequestEntryData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	startDate, err := timesheetStart(req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	entries, more, err := api.ListTimeOffRequestEntries(ctx, qbtime.ListTimeOffRequestEntriesParams{
+		StartDate:        startDate.Format("2006-01-02"),
+		Page:             req.Page,
+		SupplementalData: "no",
+		ModifiedSince:    req.ModifiedSince,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	var items []any
+
+	for _, entry := range entries {
+		if !entry.Active {
+			if req.Sync == "delta" {
+				items = append(items, removeItem(entry.Id.String()))
+			}
+			continue
+		}
+
+		item := timeOffRequestEntryItem{
+			Id:               entry.Id.String(),
+			TimeID:           entry.Id.String(),
+			TimeOffRequestID: entry.TimeOffRequestID.String(),
+			UserID:           entry.UserID.String(),
+			JobcodeID:        relatedID(entry.JobcodeID.String()),
+			ApproverUserID:   relatedID(entry.ApproverUserID.String()),
+			Status:           entry.Status,
+			Date:             entry.Date,
+			StartTime:        entry.StartTime,
+			EndTime:          entry.EndTime,
+			Duration:         float64(entry.Duration) / 3600,
+			EntryMethod:      entry.EntryMethod,
+		}
+		if req.Sync == "delta" {
+			item.SyncAction = "SET"
+		}
+		items = append(items, item)
+	}
+
+	return items, more, nil
+}
@@ -0,0 +1,265 @@
+package synchronizer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
+)
+
+type timesheetItem struct {
+	Id          string   `json:"id"`
+	TimeID      string   `json:"timeId"`
+	UserID      string   `json:"user_id"`
+	JobcodeID   string   `json:"jobcode_id"`
+	UserName    string   `json:"user_name"`
+	JobcodeName string   `json:"jobcode_name"`
+	Start       string   `json:"start,omitempty"`
+	End         string   `json:"end,omitempty"`
+	Date        string   `json:"date"`
+	Duration    float64  `json:"duration"`
+	Type        string   `json:"type"`
+	OnTheClock  bool     `json:"on_the_clock"`
+	Notes       string   `json:"notes"`
+	Files       []string `json:"files"`
+	Submitted   bool     `json:"submitted"`
+	Approved    bool     `json:"approved"`
+	State       string   `json:"approval_state"`
+	SyncAction  string   `json:"__syncAction,omitempty"`
+}
+
+// timesheetStart returns the date timesheets are synced from, the filter is read as a date in the company's
+// time zone. Jan 1, 2020 is used when the filter is earlier or empty
+func timesheetStart(req syncRequest) (time.Time, error) {
+	startDate := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	if val, ok := req.Filter["timesheetStart"].(string); ok {
+		filterStart, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unable to parse timesheet start filter: %w", err)
+		}
+		if filterDate := calendarDate(filterStart, req.location()); filterDate.After(startDate) {
+			startDate = filterDate
+		}
+	}
+
+	return startDate, nil
+}
+
+func timesheetData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	startDate, err := timesheetStart(req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// supplemental data resolves the user and jobcode names even when those types aren't synced
+	timesheets, supplemental, more, err := api.ListTimesheets(ctx, qbtime.ListTimesheetsParams{
+		Ids:              strings.Join(req.Ids, ","),
+		UserIds:          strings.Join(filterIds(req.Filter, "users"), ","),
+		GroupIds:         strings.Join(filterIds(req.Filter, "groups"), ","),
+		JobcodeIds:       strings.Join(filterIds(req.Filter, "jobcodes"), ","),
+		StartDate:        startDate.Format("2006-01-02"),
+		OnTheClock:       "both",
+		Page:             req.Page,
+		SupplementalData: "yes",
+		ModifiedSince:    req.ModifiedSince,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	lookup := newCustomFieldItemLookup(ctx, api, req)
+
+	items, err := timesheetItems(timesheets, supplemental, lookup, req.Sync)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// approving or submitting time changes the user rather than their timesheets, users modified since the
+	// last sync are paged alongside modified timesheets and their recent timesheets are synced again
+	if req.Sync == "delta" && len(req.Ids) == 0 {
+		synced := map[string]bool{}
+		for _, timesheet := range timesheets {
+			synced[timesheet.Id.String()] = true
+		}
+
+		approvalItems, moreApprovals, err := approvalChangedTimesheets(ctx, api, req, startDate, lookup, synced)
+		if err != nil {
+			return nil, false, err
+		}
+		items = append(items, approvalItems...)
+
+		more = more || moreApprovals
+	}
+
+	// webhook requests are told about deleted timesheets directly
+	if req.Sync == "delta" && len(req.Ids) == 0 {
+		// deleted timesheets are paged alongside modified timesheets, more pages are requested until both are exhausted
+		deleted, moreDeleted, err := api.ListDeletedTimesheets(ctx, qbtime.ListDeletedTimesheetsParams{
+			Page:             req.Page,
+			SupplementalData: "no",
+			ModifiedSince:    req.ModifiedSince,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+
+		for _, timesheet := range deleted {
+			items = append(items, removeItem(timesheet.Id.String()))
+		}
+
+		more = more || moreDeleted
+	}
+
+	return items, more, nil
+}
+
+// approvalWindow is how far back timesheets are synced again when their user is modified
+const approvalWindow = 31 * 24 * time.Hour
+
+// approvalUsersPerPage is how many modified users are checked for approval changes with each page of timesheets
+const approvalUsersPerPage = 25
+
+// approvalResyncRange returns the dates of a user's timesheets that are synced again when the user changes,
+// from windowStart up to the later of their submitted and approved dates
+func approvalResyncRange(user qbtime.User, windowStart time.Time) (time.Time, time.Time, bool) {
+	var end time.Time
+	for _, to := range []string{user.SubmittedTo, user.ApprovedTo} {
+		if toDate, err := time.Parse("2006-01-02", to); err == nil && toDate.After(end) {
+			end = toDate
+		}
+	}
+	if end.Before(windowStart) {
+		return time.Time{}, time.Time{}, false
+	}
+	return windowStart, end, true
+}
+
+// approvalState returns whether a timesheet's date falls within its user's submitted and approved dates
+func approvalState(timesheet qbtime.Timesheet, user qbtime.User) (bool, bool) {
+	date, err := time.Parse("2006-01-02", timesheet.Date)
+	if err != nil {
+		return false, false
+	}
+
+	covers := func(to string) bool {
+		toDate, err := time.Parse("2006-01-02", to)
+		return err == nil && !date.After(toDate)
+	}
+
+	return covers(user.SubmittedTo), covers(user.ApprovedTo)
+}
+
+func timesheetItems(timesheets []qbtime.Timesheet, supplemental qbtime.Supplemental, lookup *customFieldItemLookup, sync string) ([]any, error) {
+	var items []any
+
+	for _, timesheet := range timesheets {
+		submitted, approved := approvalState(timesheet, supplemental.Users[timesheet.UserID.String()])
+
+		state := "Open"
+		if approved {
+			state = "Approved"
+		} else if submitted {
+			state = "Submitted"
+		}
+
+		item := timesheetItem{
+			Id:          timesheet.Id.String(),
+			TimeID:      timesheet.Id.String(),
+			UserID:      timesheet.UserID.String(),
+			JobcodeID:   relatedID(timesheet.JobcodeID.String()),
+			UserName:    supplemental.Users[timesheet.UserID.String()].Name,
+			JobcodeName: supplemental.Jobcodes[relatedID(timesheet.JobcodeID.String())].Name,
+			Start:       timesheet.Start,
+			End:         timesheet.End,
+			Date:        timesheet.Date,
+			Duration:    float64(timesheet.Duration) / 3600,
+			Type:        timesheet.Type,
+			OnTheClock:  timesheet.OnTheClock,
+			Notes:       timesheet.Notes,
+			Files:       fileURLs(timesheet.AttachedFiles),
+			Submitted:   submitted,
+			Approved:    approved,
+			State:       state,
+		}
+		if sync == "delta" {
+			item.SyncAction = "SET"
+		}
+		withFields, err := withCustomFields(item, timesheet.CustomFields, lookup)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, withFields)
+	}
+
+	return items, nil
+}
+
+// approvalChangedTimesheets returns the recent timesheets of a page of users modified since the last sync,
+// timesheets in synced are skipped since they are already part of the response
+func approvalChangedTimesheets(ctx context.Context, api *qbtime.Client, req syncRequest, startDate time.Time, lookup *customFieldItemLookup, synced map[string]bool) ([]any, bool, error) {
+	users, _, more, err := api.ListUsers(ctx, qbtime.ListUsersParams{
+		Ids:              strings.Join(filterIds(req.Filter, "users"), ","),
+		GroupIds:         strings.Join(filterIds(req.Filter, "groups"), ","),
+		Active:           "both",
+		Page:             req.Page,
+		Limit:            approvalUsersPerPage,
+		SupplementalData: "no",
+		ModifiedSince:    req.ModifiedSince,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	windowStart := req.today().Add(-approvalWindow)
+	if startDate.After(windowStart) {
+		windowStart = startDate
+	}
+
+	var items []any
+
+	for _, user := range users {
+		start, end, ok := approvalResyncRange(user, windowStart)
+		if !ok {
+			continue
+		}
+
+		for page := 1; ; page++ {
+			timesheets, supplemental, morePages, err := api.ListTimesheets(ctx, qbtime.ListTimesheetsParams{
+				UserIds:          user.Id.String(),
+				JobcodeIds:       strings.Join(filterIds(req.Filter, "jobcodes"), ","),
+				StartDate:        start.Format("2006-01-02"),
+				EndDate:          end.Format("2006-01-02"),
+				OnTheClock:       "both",
+				Page:             page,
+				SupplementalData: "yes",
+			})
+			if err != nil {
+				return nil, false, err
+			}
+
+			var resynced []qbtime.Timesheet
+			for _, timesheet := range timesheets {
+				if synced[timesheet.Id.String()] {
+					continue
+				}
+				synced[timesheet.Id.String()] = true
+				resynced = append(resynced, timesheet)
+			}
+
+			pageItems, err := timesheetItems(resynced, supplemental, lookup, req.Sync)
+			if err != nil {
+				return nil, false, err
+			}
+			items = append(items, pageItems...)
+
+			if !morePages {
+				break
+			}
+		}
+	}
+
+	return items, more, nil
+}
@@ -0,0 +1,64 @@
+package synchronizer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
+)
+
+func TestApprovalResyncRange(t *testing.T) {
+	windowStart := date(2024, time.March, 1)
+
+	tests := []struct {
+		name        string
+		submittedTo string
+		approvedTo  string
+		wantEnd     time.Time
+		wantOk      bool
+	}{
+		{name: "submitted later", submittedTo: "2024-03-15", approvedTo: "2024-03-08", wantEnd: date(2024, time.March, 15), wantOk: true},
+		{name: "approved later", submittedTo: "2024-03-08", approvedTo: "2024-03-10", wantEnd: date(2024, time.March, 10), wantOk: true},
+		{name: "window start", submittedTo: "2024-03-01", approvedTo: "0000-00-00", wantEnd: windowStart, wantOk: true},
+		{name: "before window", submittedTo: "2024-02-20", approvedTo: "2024-02-10"},
+		{name: "never", submittedTo: "0000-00-00", approvedTo: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := qbtime.User{SubmittedTo: tt.submittedTo, ApprovedTo: tt.approvedTo}
+			start, end, ok := approvalResyncRange(user, windowStart)
+			if ok != tt.wantOk {
+				t.Fatalf("approvalResyncRange() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if ok && (!start.Equal(windowStart) || !end.Equal(tt.wantEnd)) {
+				t.Errorf("approvalResyncRange() = %s - %s, want %s - %s",
+					start.Format("2006-01-02"), end.Format("2006-01-02"),
+					windowStart.Format("2006-01-02"), tt.wantEnd.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestApprovalState(t *testing.T) {
+	user := qbtime.User{SubmittedTo: "2024-03-15", ApprovedTo: "2024-03-08"}
+
+	tests := []struct {
+		date          string
+		wantSubmitted bool
+		wantApproved  bool
+	}{
+		{"2024-03-08", true, true},
+		{"2024-03-09", true, false},
+		{"2024-03-15", true, false},
+		{"2024-03-16", false, false},
+		{"", false, false},
+	}
+
+	for _, tt := range tests {
+		submitted, approved := approvalState(qbtime.Timesheet{Date: tt.date}, user)
+		if submitted != tt.wantSubmitted || approved != tt.wantApproved {
+			t.Errorf("approvalState(%q) = %v, %v, want %v, %v", tt.date, submitted, approved, tt.wantSubmitted, tt.wantApproved)
+		}
+	}
+}
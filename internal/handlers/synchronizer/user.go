@@ -0,0 +1,104 @@
+package synchronizer
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
+)
+
+type userItem struct {
+	Id          string `json:"id"`
+	TimeID      string `json:"timeId"`
+	DiplayName  string `json:"display_name"`
+	FirstName   string `json:"first_name"`
+	LastName    string `json:"last_name"`
+	Active      bool   `json:"active"`
+	Email       string `json:"email"`
+	LastActive  string `json:"last_active"`
+	SyncAction  string `json:"__syncAction,omitempty"`
+	GroupID     string `json:"group_id"`
+	GroupName   string `json:"group_name"`
+	SubmittedTo string `json:"submitted_to,omitempty"`
+	ApprovedTo  string `json:"approved_to,omitempty"`
+}
+
+func userData(ctx context.Context, api *qbtime.Client, req syncRequest) ([]any, bool, error) {
+	includeInactive, _ := req.Filter["inactiveUsers"].(bool)
+
+	active := activeParam(req)
+	if includeInactive {
+		active = "both"
+	}
+
+	// users moved out of the selected groups are removed like inactive users
+	groupIds := filterIds(req.Filter, "groups")
+	listGroupIds := groupIds
+	if req.Sync == "delta" {
+		listGroupIds = nil
+	}
+
+	users, supplemental, more, err := api.ListUsers(ctx, qbtime.ListUsersParams{
+		Ids:              strings.Join(req.Ids, ","),
+		GroupIds:         strings.Join(listGroupIds, ","),
+		Active:           active,
+		Page:             req.Page,
+		SupplementalData: "yes",
+		ModifiedSince:    req.ModifiedSince,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	userIds := filterIds(req.Filter, "users")
+
+	lookup := newCustomFieldItemLookup(ctx, api, req)
+
+	var items []any
+
+	for _, user := range users {
+		if !filterIncludes(userIds, user.Id.String()) {
+			continue
+		}
+
+		keep := (user.Active || includeInactive) && filterIncludes(groupIds, user.GroupID.String())
+		if item, ok := removedItem(req, keep, user.Id.String()); ok {
+			items = append(items, item)
+			continue
+		}
+
+		item := userItem{
+			Id:          user.Id.String(),
+			TimeID:      user.Id.String(),
+			DiplayName:  user.Name,
+			FirstName:   user.FirstName,
+			LastName:    user.LastName,
+			Active:      user.Active,
+			Email:       user.Email,
+			LastActive:  user.LastActive,
+			GroupID:     user.GroupID.String(),
+			GroupName:   supplemental.Groups[user.GroupID.String()].Name,
+			SubmittedTo: validDate(user.SubmittedTo),
+			ApprovedTo:  validDate(user.ApprovedTo),
+		}
+		if req.Sync == "delta" {
+			item.SyncAction = "SET"
+		}
+		withFields, err := withCustomFields(item, user.CustomFields, lookup)
+		if err != nil {
+			return nil, false, err
+		}
+		items = append(items, withFields)
+	}
+
+	return items, more, nil
+}
+
+// validDate drops dates the API uses for never, such as 0000-00-00
+func validDate(date string) string {
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return ""
+	}
+	return date
+}
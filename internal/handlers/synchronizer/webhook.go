@@ -0,0 +1,231 @@
+package synchronizer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
+	"github.com/tommyhedley/fiberytsheets/internal/utils"
+)
+
+const defaultFiberyWebhookURL = "https://webhooks.fibery.io/webhooks/async"
+
+// webhookObjectTypes maps Fibery sync types to the Quickbooks Time object types used by webhooks
+var webhookObjectTypes = map[string]string{
+	"user":      "users",
+	"group":     "groups",
+	"timesheet": "timesheets",
+	"jobcode":   "jobcodes",
+}
+
+// installedWebhook is returned to Fibery on install and sent back on uninstall
+type installedWebhook struct {
+	Id        string `json:"id"`
+	WebhookID string `json:"webhookId"`
+}
+
+func WebhookInstall(client *qbtime.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type parameters struct {
+			Types   []string `json:"types"`
+			Account struct {
+				AccessToken string `json:"access_token"`
+			} `json:"account"`
+			Webhook struct {
+				Id          string `json:"id"`
+				WorkspaceID string `json:"workspaceId"`
+			} `json:"webhook"`
+		}
+
+		decoder := json.NewDecoder(r.Body)
+		params := parameters{}
+		err := decoder.Decode(&params)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("unable to decode request parameters: %v", err))
+			return
+		}
+
+		var objectTypes []string
+		for _, t := range params.Types {
+			if objectType, ok := webhookObjectTypes[t]; ok {
+				objectTypes = append(objectTypes, objectType)
+			}
+		}
+		if len(objectTypes) == 0 {
+			utils.RespondWithError(w, http.StatusBadRequest, "no webhook supported types requested")
+			return
+		}
+
+		baseURL := os.Getenv("FIBERY_WEBHOOK_URL")
+		if baseURL == "" {
+			baseURL = defaultFiberyWebhookURL
+		}
+
+		webhooks, err := client.WithToken(params.Account.AccessToken).CreateWebhooks(r.Context(), []qbtime.CreateWebhook{
+			{
+				Name:        fmt.Sprintf("Fibery %s", params.Webhook.WorkspaceID),
+				URL:         fmt.Sprintf("%s/%s", strings.TrimSuffix(baseURL, "/"), params.Webhook.Id),
+				Enabled:     true,
+				ObjectTypes: objectTypes,
+			},
+		})
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("error with create webhook request: %v", err))
+			return
+		}
+		if len(webhooks) == 0 {
+			utils.RespondWithError(w, http.StatusBadRequest, "no webhook created")
+			return
+		}
+
+		webhook := webhooks[0]
+		if webhook.StatusCode > 299 {
+			utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("unable to create webhook: %s %s", webhook.StatusMessage, webhook.StatusExtra))
+			return
+		}
+
+		utils.RespondWithJSON(w, http.StatusOK, installedWebhook{
+			Id:        params.Webhook.Id,
+			WebhookID: webhook.Id.String(),
+		})
+	}
+}
+
+func WebhookUninstall(client *qbtime.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type parameters struct {
+			Account struct {
+				AccessToken string `json:"access_token"`
+			} `json:"account"`
+			Webhook installedWebhook `json:"webhook"`
+		}
+
+		decoder := json.NewDecoder(r.Body)
+		params := parameters{}
+		err := decoder.Decode(&params)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("unable to decode request parameters: %v", err))
+			return
+		}
+
+		if params.Webhook.WebhookID == "" {
+			utils.RespondWithError(w, http.StatusBadRequest, "missing webhook id")
+			return
+		}
+
+		err = client.WithToken(params.Account.AccessToken).DeleteWebhooks(r.Context(), params.Webhook.WebhookID)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("error with delete webhook request: %v", err))
+			return
+		}
+
+		utils.RespondWithJSON(w, http.StatusOK, nil)
+	}
+}
+
+// WebhookTransform converts a Quickbooks Time webhook payload into Fibery items, changed objects are
+// fetched by id and handled like a delta sync while deleted objects are removed directly
+func WebhookTransform(client *qbtime.Client) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		type parameters struct {
+			Types   []string       `json:"types"`
+			Filter  map[string]any `json:"filter"`
+			Account struct {
+				AccessToken string `json:"access_token"`
+			} `json:"account"`
+			Payload qbtime.WebhookPayload `json:"payload"`
+		}
+		type response struct {
+			Data map[string][]any `json:"data"`
+		}
+
+		decoder := json.NewDecoder(r.Body)
+		params := parameters{}
+		err := decoder.Decode(&params)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("unable to decode request parameters: %v", err))
+			return
+		}
+
+		ctx, cancel := client.WithBudget(r.Context())
+		defer cancel()
+
+		api := client.WithToken(params.Account.AccessToken)
+		data := map[string][]any{}
+
+		// the company's time zone is only looked up when changed objects are fetched
+		var location *time.Location
+
+		for _, t := range params.Types {
+			objectType, ok := webhookObjectTypes[t]
+			if !ok {
+				continue
+			}
+
+			var changed []string
+			for _, event := range params.Payload.Data {
+				if event.Type != objectType {
+					continue
+				}
+				if event.Action == "delete" {
+					data[t] = append(data[t], removeItem(event.Object.Id.String()))
+					continue
+				}
+				changed = append(changed, event.Object.Id.String())
+			}
+
+			if len(changed) == 0 {
+				continue
+			}
+
+			if location == nil {
+				location = companyLocation(ctx, api)
+			}
+
+			items, err := webhookItems(ctx, api, dataTypes[t], params.Types, params.Filter, changed, location)
+			if err != nil {
+				if qbtime.IsTemporary(err) {
+					utils.RespondWithTryLater(w, http.StatusServiceUnavailable, fmt.Sprintf("temporary error with %s request: %v", t, err))
+					return
+				}
+				utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("error with %s request: %v", t, err))
+				return
+			}
+			data[t] = append(data[t], items...)
+		}
+
+		utils.RespondWithJSON(w, http.StatusOK, response{
+			Data: data,
+		})
+	}
+}
+
+// webhookItems fetches every page of the changed objects as a delta sync
+func webhookItems(ctx context.Context, api *qbtime.Client, dataType dataFunc, types []string, filter map[string]any, ids []string, location *time.Location) ([]any, error) {
+	var items []any
+	state := map[string]string{}
+
+	for page := 1; ; page++ {
+		pageItems, more, err := dataType(ctx, api, syncRequest{
+			Types:    types,
+			Filter:   filter,
+			Page:     page,
+			Sync:     "delta",
+			Ids:      ids,
+			Location: location,
+			State:    state,
+		})
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, pageItems...)
+		if !more {
+			return items, nil
+		}
+	}
+}
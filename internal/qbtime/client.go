@@ -0,0 +1,243 @@
+package qbtime
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/go-querystring/query"
+)
+
+const DefaultBaseURL = "https://rest.tsheets.com/api/v1"
+
+// Client makes requests to the Quickbooks Time API. A Client is shared across handlers,
+// WithToken is used to get a copy authorized for a single account.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	Retry      RetryPolicy
+	token      string
+}
+
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		HTTPClient: httpClient,
+		Retry:      DefaultRetryPolicy,
+	}
+}
+
+func (c *Client) WithToken(token string) *Client {
+	clone := *c
+	clone.token = token
+	return &clone
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, params any, body io.Reader) (*http.Request, error) {
+	baseURL, err := url.Parse(c.BaseURL + path)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing base url: %w", err)
+	}
+
+	if params != nil {
+		queryParams, err := query.Values(params)
+		if err != nil {
+			return nil, fmt.Errorf("error extracting query parameters: %w", err)
+		}
+		baseURL.RawQuery = queryParams.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, baseURL.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	if c.token != "" {
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	}
+
+	return req, nil
+}
+
+// WithBudget returns a context that ends once the client's retry budget is spent, handlers use it so
+// every call made for a single Fibery request shares one budget
+func (c *Client) WithBudget(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, c.Retry.Budget)
+}
+
+// do executes the request and returns the response if it has a successful status code.
+// Rate limited requests are always retried, 5xx and transport failures are only retried for GET and
+// DELETE requests since the server may have already made the change. The caller is responsible for closing the response body
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	return c.doRetry(req, req.Method == http.MethodGet || req.Method == http.MethodDelete)
+}
+
+// doRetry is do for requests whose idempotency can't be told from the method, such as reports requested with a POST.
+// Retries stop when the next attempt would start after the context's deadline, or the retry budget when there is none
+func (c *Client) doRetry(req *http.Request, idempotent bool) (*http.Response, error) {
+	deadline, ok := req.Context().Deadline()
+	if !ok {
+		deadline = time.Now().Add(c.Retry.Budget)
+	}
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("error resetting request body: %w", err)
+			}
+			req.Body = body
+		}
+
+		res, err := c.HTTPClient.Do(req)
+		if err != nil {
+			err = NewRequestError(fmt.Errorf("error executing request: %w", err), 0)
+			if !idempotent {
+				return nil, err
+			}
+		} else if res.StatusCode > 299 && res.StatusCode != http.StatusMultiStatus {
+			res.Body.Close()
+			if res.StatusCode == 429 {
+				err = NewRequestError(fmt.Errorf("rate limit reached: %d", res.StatusCode), res.StatusCode)
+			} else {
+				err = NewRequestError(fmt.Errorf("request error: %d", res.StatusCode), res.StatusCode)
+			}
+			if !retryableStatus(res.StatusCode) || (!idempotent && res.StatusCode != 429) {
+				return nil, err
+			}
+		} else {
+			return res, nil
+		}
+
+		if req.Context().Err() != nil || attempt >= c.Retry.MaxRetries {
+			return nil, err
+		}
+
+		wait := c.Retry.backoff(attempt, res)
+		if time.Now().Add(wait).After(deadline) {
+			return nil, err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+// postJSON sends data as a JSON body, idempotent is set for read only requests so they are retried like a GET
+func (c *Client) postJSON(ctx context.Context, path string, data any, idempotent bool) (*http.Response, error) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding request body: %w", err)
+	}
+
+	req, err := c.newRequest(ctx, "POST", path, nil, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	return c.doRetry(req, idempotent)
+}
+
+func getList[Res any](ctx context.Context, c *Client, path string, params any, fieldName string) ([]Res, bool, error) {
+	response, err := getPage[Res](ctx, c, path, params, fieldName)
+	if err != nil {
+		return nil, false, err
+	}
+
+	items, more := response.ExtractItems()
+	return items, more, nil
+}
+
+// getListWithSupplemental is getList for requests made with supplemental_data=yes
+func getListWithSupplemental[Res any](ctx context.Context, c *Client, path string, params any, fieldName string) ([]Res, Supplemental, bool, error) {
+	response, err := getPage[Res](ctx, c, path, params, fieldName)
+	if err != nil {
+		return nil, Supplemental{}, false, err
+	}
+
+	supplemental, err := NewSupplemental(response.SupplementalData)
+	if err != nil {
+		return nil, Supplemental{}, false, err
+	}
+
+	items, more := response.ExtractItems()
+	return items, supplemental, more, nil
+}
+
+// getObject is used for endpoints whose result is a single object rather than a list keyed by id
+func getObject[Res any](ctx context.Context, c *Client, path string, params any, fieldName string) (Res, error) {
+	var result Res
+
+	req, err := c.newRequest(ctx, "GET", path, params, nil)
+	if err != nil {
+		return result, err
+	}
+
+	res, err := c.do(req)
+	if err != nil {
+		return result, err
+	}
+	defer res.Body.Close()
+
+	var response struct {
+		Results map[string]json.RawMessage `json:"results"`
+	}
+	err = json.NewDecoder(res.Body).Decode(&response)
+	if err != nil {
+		return result, fmt.Errorf("unable to decode response: %w", err)
+	}
+
+	data, ok := response.Results[fieldName]
+	if !ok {
+		return result, fmt.Errorf("expected field '%s' not found in results", fieldName)
+	}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return result, fmt.Errorf("unable to decode %s: %w", fieldName, err)
+	}
+
+	return result, nil
+}
+
+func getPage[Res any](ctx context.Context, c *Client, path string, params any, fieldName string) (ResponseData[Res], error) {
+	req, err := c.newRequest(ctx, "GET", path, params, nil)
+	if err != nil {
+		return ResponseData[Res]{}, err
+	}
+
+	start := time.Now()
+
+	res, err := c.do(req)
+	if err != nil {
+		return ResponseData[Res]{}, err
+	}
+	defer res.Body.Close()
+
+	fmt.Printf("%s data response time: %s\n", fieldName, time.Since(start))
+
+	var response ResponseData[Res]
+	err = response.DecodeBody(res.Body, fieldName)
+	if err != nil {
+		return ResponseData[Res]{}, fmt.Errorf("unable to decode response: %w", err)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,67 @@
+package qbtime
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type CustomField struct {
+	Id        json.Number `json:"id" type:"string"`
+	Name      string      `json:"name"`
+	ShortCode string      `json:"short_code"`
+	Active    bool        `json:"active"`
+	Required  bool        `json:"required"`
+	AppliesTo string      `json:"applies_to"`
+	Type      string      `json:"type"`
+}
+
+type CustomFieldItem struct {
+	Id            json.Number `json:"id" type:"string"`
+	CustomFieldID json.Number `json:"customfield_id" type:"string"`
+	Name          string      `json:"name"`
+	ShortCode     string      `json:"short_code"`
+	Active        bool        `json:"active"`
+}
+
+type ListCustomFieldsParams struct {
+	Active           string `url:"active"`
+	Page             int    `url:"page"`
+	Limit            int    `url:"limit,omitempty"`
+	SupplementalData string `url:"supplemental_data"`
+	ModifiedSince    string `url:"modified_since,omitempty"`
+}
+
+type ListCustomFieldItemsParams struct {
+	CustomFieldID    string `url:"customfield_id"`
+	Active           string `url:"active"`
+	Page             int    `url:"page"`
+	SupplementalData string `url:"supplemental_data"`
+	ModifiedSince    string `url:"modified_since,omitempty"`
+}
+
+// CustomFieldValues maps custom field ids to the value set on a record. The API returns an empty
+// array instead of an object when a record has no values
+type CustomFieldValues map[string]string
+
+func (v *CustomFieldValues) UnmarshalJSON(data []byte) error {
+	var values map[string]string
+	if err := json.Unmarshal(data, &values); err == nil {
+		*v = values
+		return nil
+	}
+
+	var empty []any
+	if err := json.Unmarshal(data, &empty); err != nil {
+		return err
+	}
+	*v = nil
+	return nil
+}
+
+func (c *Client) ListCustomFields(ctx context.Context, params ListCustomFieldsParams) ([]CustomField, bool, error) {
+	return getList[CustomField](ctx, c, "/customfields", &params, "customfields")
+}
+
+func (c *Client) ListCustomFieldItems(ctx context.Context, params ListCustomFieldItemsParams) ([]CustomFieldItem, bool, error) {
+	return getList[CustomFieldItem](ctx, c, "/customfielditems", &params, "customfielditems")
+}
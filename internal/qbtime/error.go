@@ -0,0 +1,44 @@
+package qbtime
+
+import "errors"
+
+type RequestError struct {
+	StatusCode int
+	RateLimit  bool
+	Err        error
+}
+
+func (e *RequestError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+func NewRequestError(err error, statusCode int) *RequestError {
+	return &RequestError{
+		StatusCode: statusCode,
+		RateLimit:  statusCode == 429,
+		Err:        err,
+	}
+}
+
+// IsRateLimit reports whether err was caused by Quickbooks Time rate limiting the request
+func IsRateLimit(err error) bool {
+	var requestError *RequestError
+	if errors.As(err, &requestError) {
+		return requestError.RateLimit
+	}
+	return false
+}
+
+// IsTemporary reports whether err was a rate limit, 5xx or transport failure that outlasted the
+// client's retries, these requests are expected to succeed if tried again later
+func IsTemporary(err error) bool {
+	var requestError *RequestError
+	if errors.As(err, &requestError) {
+		return requestError.StatusCode == 0 || retryableStatus(requestError.StatusCode)
+	}
+	return false
+}
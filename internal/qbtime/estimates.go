@@ -0,0 +1,42 @@
+package qbtime
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type Estimate struct {
+	Id        json.Number `json:"id" type:"string"`
+	ProjectID json.Number `json:"project_id" type:"string"`
+	By        string      `json:"by"`
+	Active    bool        `json:"active"`
+}
+
+type EstimateItem struct {
+	Id               json.Number `json:"id" type:"string"`
+	EstimateID       json.Number `json:"estimate_id" type:"string"`
+	EstimatedSeconds int         `json:"estimated_seconds"`
+	Active           bool        `json:"active"`
+}
+
+type ListEstimatesParams struct {
+	ProjectIds       string `url:"project_ids"`
+	Active           string `url:"active"`
+	Page             int    `url:"page"`
+	SupplementalData string `url:"supplemental_data"`
+}
+
+type ListEstimateItemsParams struct {
+	EstimateIds      string `url:"estimate_ids"`
+	Active           string `url:"active"`
+	Page             int    `url:"page"`
+	SupplementalData string `url:"supplemental_data"`
+}
+
+func (c *Client) ListEstimates(ctx context.Context, params ListEstimatesParams) ([]Estimate, bool, error) {
+	return getList[Estimate](ctx, c, "/estimates", &params, "estimates")
+}
+
+func (c *Client) ListEstimateItems(ctx context.Context, params ListEstimateItemsParams) ([]EstimateItem, bool, error) {
+	return getList[EstimateItem](ctx, c, "/estimates/estimate_items", &params, "estimate_items")
+}
@@ -0,0 +1,67 @@
+package qbtime
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+)
+
+type File struct {
+	Id               json.Number   `json:"id" type:"string"`
+	FileName         string        `json:"file_name"`
+	FileDescription  string        `json:"file_description"`
+	UploadedByUserID json.Number   `json:"uploaded_by_user_id" type:"string"`
+	Size             int           `json:"size"`
+	Created          string        `json:"created"`
+	Active           bool          `json:"active"`
+	LinkedObjects    LinkedObjects `json:"linked_objects"`
+}
+
+// LinkedObjects maps object types to the ids of the objects a record is attached to. The API
+// returns an empty array instead of an object when a record isn't attached to anything
+type LinkedObjects map[string][]json.Number
+
+func (l *LinkedObjects) UnmarshalJSON(data []byte) error {
+	var objects map[string][]json.Number
+	if err := json.Unmarshal(data, &objects); err == nil {
+		*l = objects
+		return nil
+	}
+
+	var empty []any
+	if err := json.Unmarshal(data, &empty); err != nil {
+		return err
+	}
+	*l = nil
+	return nil
+}
+
+type ListFilesParams struct {
+	Active           string `url:"active"`
+	Page             int    `url:"page"`
+	SupplementalData string `url:"supplemental_data"`
+	ModifiedSince    string `url:"modified_since,omitempty"`
+}
+
+func (c *Client) ListFiles(ctx context.Context, params ListFilesParams) ([]File, bool, error) {
+	return getList[File](ctx, c, "/files", &params, "files")
+}
+
+// DownloadFile returns the raw contents of a file and its content type, the caller is responsible for closing the body
+func (c *Client) DownloadFile(ctx context.Context, id string) (io.ReadCloser, string, error) {
+	type params struct {
+		Id string `url:"id"`
+	}
+
+	req, err := c.newRequest(ctx, "GET", "/files/raw", &params{Id: id}, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	res, err := c.do(req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return res.Body, res.Header.Get("Content-Type"), nil
+}
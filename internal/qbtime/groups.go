@@ -0,0 +1,24 @@
+package qbtime
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type Group struct {
+	Id     json.Number `json:"id" type:"string"`
+	Name   string      `json:"name"`
+	Active bool        `json:"active"`
+}
+
+type ListGroupsParams struct {
+	Ids              string `url:"ids,omitempty"`
+	Active           string `url:"active,omitempty"`
+	Page             int    `url:"page"`
+	SupplementalData string `url:"supplemental_data"`
+	ModifiedSince    string `url:"modified_since,omitempty"`
+}
+
+func (c *Client) ListGroups(ctx context.Context, params ListGroupsParams) ([]Group, bool, error) {
+	return getList[Group](ctx, c, "/groups", &params, "groups")
+}
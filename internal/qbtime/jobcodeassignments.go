@@ -0,0 +1,26 @@
+package qbtime
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type JobcodeAssignment struct {
+	Id        json.Number `json:"id" type:"string"`
+	UserID    json.Number `json:"user_id" type:"string"`
+	JobcodeID json.Number `json:"jobcode_id" type:"string"`
+	Active    bool        `json:"active"`
+	Created   string      `json:"created"`
+}
+
+type ListJobcodeAssignmentsParams struct {
+	UserIds          string `url:"user_ids,omitempty"`
+	Active           string `url:"active"`
+	Page             int    `url:"page"`
+	SupplementalData string `url:"supplemental_data"`
+	ModifiedSince    string `url:"modified_since,omitempty"`
+}
+
+func (c *Client) ListJobcodeAssignments(ctx context.Context, params ListJobcodeAssignmentsParams) ([]JobcodeAssignment, bool, error) {
+	return getList[JobcodeAssignment](ctx, c, "/jobcode_assignments", &params, "jobcode_assignments")
+}
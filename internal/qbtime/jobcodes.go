@@ -0,0 +1,33 @@
+package qbtime
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type Jobcode struct {
+	Id            json.Number       `json:"id" type:"string"`
+	ParentID      json.Number       `json:"parent_id" type:"string"`
+	Name          string            `json:"name"`
+	ShortCode     string            `json:"short_code"`
+	Type          string            `json:"type"`
+	Billable      bool              `json:"billable"`
+	BillableRate  float64           `json:"billable_rate"`
+	HasChildren   bool              `json:"has_children"`
+	AssignedToAll bool              `json:"assigned_to_all"`
+	Active        bool              `json:"active"`
+	CustomFields  CustomFieldValues `json:"customfields"`
+}
+
+type ListJobcodesParams struct {
+	Ids              string `url:"ids,omitempty"`
+	Active           string `url:"active"`
+	Type             string `url:"type"`
+	Page             int    `url:"page"`
+	SupplementalData string `url:"supplemental_data"`
+	ModifiedSince    string `url:"modified_since,omitempty"`
+}
+
+func (c *Client) ListJobcodes(ctx context.Context, params ListJobcodesParams) ([]Jobcode, bool, error) {
+	return getList[Jobcode](ctx, c, "/jobcodes", &params, "jobcodes")
+}
@@ -0,0 +1,60 @@
+package qbtime
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type Location struct {
+	Id               json.Number `json:"id" type:"string"`
+	Label            string      `json:"label"`
+	Addr1            string      `json:"addr1"`
+	Addr2            string      `json:"addr2"`
+	City             string      `json:"city"`
+	State            string      `json:"state"`
+	Zip              string      `json:"zip"`
+	Country          string      `json:"country"`
+	FormattedAddress string      `json:"formatted_address"`
+	Latitude         float64     `json:"latitude"`
+	Longitude        float64     `json:"longitude"`
+	GeofenceConfigID json.Number `json:"geofence_config_id" type:"string"`
+	Active           bool        `json:"active"`
+}
+
+type GeofenceConfig struct {
+	Id      json.Number `json:"id" type:"string"`
+	Enabled bool        `json:"enabled"`
+	Radius  float64     `json:"radius"`
+}
+
+type Geolocation struct {
+	Id        json.Number `json:"id" type:"string"`
+	UserID    json.Number `json:"user_id" type:"string"`
+	Latitude  float64     `json:"latitude"`
+	Longitude float64     `json:"longitude"`
+	Accuracy  float64     `json:"accuracy"`
+	Source    string      `json:"source"`
+	Created   string      `json:"created"`
+}
+
+type ListLocationsParams struct {
+	Active           string `url:"active"`
+	Page             int    `url:"page"`
+	SupplementalData string `url:"supplemental_data"`
+	ModifiedSince    string `url:"modified_since,omitempty"`
+}
+
+type ListGeolocationsParams struct {
+	Page             int    `url:"page"`
+	SupplementalData string `url:"supplemental_data"`
+	ModifiedSince    string `url:"modified_since"`
+}
+
+// ListLocations returns a page of locations, their geofence configs are included when SupplementalData is "yes"
+func (c *Client) ListLocations(ctx context.Context, params ListLocationsParams) ([]Location, Supplemental, bool, error) {
+	return getListWithSupplemental[Location](ctx, c, "/locations", &params, "locations")
+}
+
+func (c *Client) ListGeolocations(ctx context.Context, params ListGeolocationsParams) ([]Geolocation, bool, error) {
+	return getList[Geolocation](ctx, c, "/geolocations", &params, "geolocations")
+}
@@ -0,0 +1,92 @@
+package qbtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-querystring/query"
+)
+
+type AccessTokenRequest struct {
+	GrantType    string `url:"grant_type"`
+	ClientId     string `url:"client_id"`
+	ClientSecret string `url:"client_secret"`
+	Code         string `url:"code"`
+	RedirectURI  string `url:"redirect_uri"`
+}
+
+type RefreshTokenRequest struct {
+	GrantType    string `url:"grant_type"`
+	ClientId     string `url:"client_id"`
+	ClientSecret string `url:"client_secret"`
+	RefreshToken string `url:"refresh_token"`
+}
+
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	TokenType    string `json:"token_type"`
+	Scope        string `json:"scope"`
+	RefreshToken string `json:"refresh_token"`
+	UserID       string `json:"user_id"`
+	CompanyID    string `json:"company_id"`
+	ClientURL    string `json:"client_url"`
+	ClientType   string `json:"client_type"`
+}
+
+func (c *Client) AuthorizeURL(clientID, redirectURI, state string) (string, error) {
+	redirectURL, err := url.Parse(c.BaseURL + "/authorize")
+	if err != nil {
+		return "", fmt.Errorf("error parsing base url: %w", err)
+	}
+
+	queryParams := url.Values{}
+	queryParams.Add("response_type", "code")
+	queryParams.Add("client_id", clientID)
+	queryParams.Add("redirect_uri", redirectURI)
+	queryParams.Add("state", state)
+
+	redirectURL.RawQuery = queryParams.Encode()
+	return redirectURL.String(), nil
+}
+
+// Grant exchanges an authorization code for an access token
+func (c *Client) Grant(ctx context.Context, params AccessTokenRequest) (Token, error) {
+	return c.grant(ctx, params)
+}
+
+// Refresh exchanges a refresh token for a new access token, the client's current token is sent as well
+func (c *Client) Refresh(ctx context.Context, params RefreshTokenRequest) (Token, error) {
+	return c.grant(ctx, params)
+}
+
+func (c *Client) grant(ctx context.Context, params any) (Token, error) {
+	body, err := query.Values(params)
+	if err != nil {
+		return Token{}, fmt.Errorf("error extracting query struct values: %w", err)
+	}
+
+	req, err := c.newRequest(ctx, "POST", "/grant", nil, strings.NewReader(body.Encode()))
+	if err != nil {
+		return Token{}, err
+	}
+
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := c.do(req)
+	if err != nil {
+		return Token{}, err
+	}
+	defer res.Body.Close()
+
+	decoder := json.NewDecoder(res.Body)
+	var resp Token
+	err = decoder.Decode(&resp)
+	if err != nil {
+		return Token{}, fmt.Errorf("unable to decode response: %w", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,73 @@
+package qbtime
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type Project struct {
+	Id              json.Number `json:"id" type:"string"`
+	JobcodeID       json.Number `json:"jobcode_id" type:"string"`
+	ParentJobcodeID json.Number `json:"parent_jobcode_id" type:"string"`
+	Name            string      `json:"name"`
+	Status          string      `json:"status"`
+	Description     string      `json:"description"`
+	StartDate       string      `json:"start_date"`
+	DueDate         string      `json:"due_date"`
+	CompletedDate   string      `json:"completed_date"`
+	Active          bool        `json:"active"`
+	LastModified    string      `json:"last_modified"`
+}
+
+type ProjectNote struct {
+	Id        json.Number   `json:"id" type:"string"`
+	ProjectID json.Number   `json:"project_id" type:"string"`
+	UserID    json.Number   `json:"user_id" type:"string"`
+	Note      string        `json:"note"`
+	Files     []json.Number `json:"files"`
+	Created   string        `json:"created"`
+	Active    bool          `json:"active"`
+}
+
+type ProjectActivity struct {
+	Id           json.Number `json:"id" type:"string"`
+	ProjectID    json.Number `json:"project_id" type:"string"`
+	UserID       json.Number `json:"user_id" type:"string"`
+	ActivityType string      `json:"activity_type"`
+	Created      string      `json:"created"`
+}
+
+type ListProjectsParams struct {
+	Active           string `url:"active"`
+	Page             int    `url:"page"`
+	Limit            int    `url:"limit,omitempty"`
+	SupplementalData string `url:"supplemental_data"`
+	ModifiedSince    string `url:"modified_since,omitempty"`
+}
+
+type ListProjectNotesParams struct {
+	ProjectID        string `url:"project_id"`
+	Active           string `url:"active"`
+	Page             int    `url:"page"`
+	SupplementalData string `url:"supplemental_data"`
+	ModifiedSince    string `url:"modified_since,omitempty"`
+}
+
+type ListProjectActivitiesParams struct {
+	ProjectID        string `url:"project_id"`
+	Page             int    `url:"page"`
+	SupplementalData string `url:"supplemental_data"`
+	ModifiedSince    string `url:"modified_since,omitempty"`
+}
+
+func (c *Client) ListProjects(ctx context.Context, params ListProjectsParams) ([]Project, bool, error) {
+	return getList[Project](ctx, c, "/projects", &params, "projects")
+}
+
+func (c *Client) ListProjectNotes(ctx context.Context, params ListProjectNotesParams) ([]ProjectNote, bool, error) {
+	return getList[ProjectNote](ctx, c, "/project_notes", &params, "project_notes")
+}
+
+func (c *Client) ListProjectActivities(ctx context.Context, params ListProjectActivitiesParams) ([]ProjectActivity, bool, error) {
+	return getList[ProjectActivity](ctx, c, "/project_activities", &params, "project_activities")
+}
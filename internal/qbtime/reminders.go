@@ -0,0 +1,46 @@
+package qbtime
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type Reminder struct {
+	Id                  json.Number `json:"id" type:"string"`
+	UserID              json.Number `json:"user_id" type:"string"`
+	ReminderType        string      `json:"reminder_type"`
+	DueTime             string      `json:"due_time"`
+	DueDaysOfWeek       string      `json:"due_days_of_week"`
+	DistributionMethods string      `json:"distribution_methods"`
+	Active              bool        `json:"active"`
+	Enabled             bool        `json:"enabled"`
+}
+
+type Notification struct {
+	Id           json.Number `json:"id" type:"string"`
+	UserID       json.Number `json:"user_id" type:"string"`
+	Message      string      `json:"message"`
+	Method       string      `json:"method"`
+	DeliveryTime string      `json:"delivery_time"`
+}
+
+type ListRemindersParams struct {
+	UserIds          string `url:"user_ids"`
+	Active           string `url:"active"`
+	Page             int    `url:"page"`
+	SupplementalData string `url:"supplemental_data"`
+	ModifiedSince    string `url:"modified_since,omitempty"`
+}
+
+type ListNotificationsParams struct {
+	Page             int    `url:"page"`
+	SupplementalData string `url:"supplemental_data"`
+}
+
+func (c *Client) ListReminders(ctx context.Context, params ListRemindersParams) ([]Reminder, bool, error) {
+	return getList[Reminder](ctx, c, "/reminders", &params, "reminders")
+}
+
+func (c *Client) ListNotifications(ctx context.Context, params ListNotificationsParams) ([]Notification, bool, error) {
+	return getList[Notification](ctx, c, "/notifications", &params, "notifications")
+}
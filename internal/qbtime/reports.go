@@ -0,0 +1,91 @@
+package qbtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type CurrentTotals struct {
+	UserID       json.Number `json:"user_id" type:"string"`
+	GroupID      json.Number `json:"group_id" type:"string"`
+	JobcodeID    json.Number `json:"jobcode_id" type:"string"`
+	TimesheetID  json.Number `json:"timesheet_id" type:"string"`
+	OnTheClock   bool        `json:"on_the_clock"`
+	ShiftSeconds int         `json:"shift_seconds"`
+	DaySeconds   int         `json:"day_seconds"`
+}
+
+type CurrentTotalsParams struct {
+	UserIds    string `json:"user_ids,omitempty"`
+	GroupIds   string `json:"group_ids,omitempty"`
+	OnTheClock string `json:"on_the_clock"`
+}
+
+// postReport requests a report, reports are returned in a single response with their related objects as supplemental data
+func postReport[Res any](ctx context.Context, c *Client, path string, params any, fieldName string) ([]Res, Supplemental, error) {
+	type request struct {
+		Data any `json:"data"`
+	}
+
+	res, err := c.postJSON(ctx, path, request{Data: params}, true)
+	if err != nil {
+		return nil, Supplemental{}, err
+	}
+	defer res.Body.Close()
+
+	var response ResponseData[Res]
+	err = response.DecodeBody(res.Body, fieldName)
+	if err != nil {
+		return nil, Supplemental{}, fmt.Errorf("unable to decode response: %w", err)
+	}
+
+	supplemental, err := NewSupplemental(response.SupplementalData)
+	if err != nil {
+		return nil, Supplemental{}, err
+	}
+
+	items, _ := response.ExtractItems()
+	return items, supplemental, nil
+}
+
+func (c *Client) CurrentTotalsReport(ctx context.Context, params CurrentTotalsParams) ([]CurrentTotals, Supplemental, error) {
+	return postReport[CurrentTotals](ctx, c, "/reports/current_totals", params, "current_totals")
+}
+
+type PayrollReport struct {
+	UserID           json.Number `json:"user_id" type:"string"`
+	StartDate        string      `json:"start_date"`
+	EndDate          string      `json:"end_date"`
+	TotalReSeconds   int         `json:"total_re_seconds"`
+	TotalOtSeconds   int         `json:"total_ot_seconds"`
+	TotalDtSeconds   int         `json:"total_dt_seconds"`
+	TotalPtoSeconds  int         `json:"total_pto_seconds"`
+	TotalWorkSeconds int         `json:"total_work_seconds"`
+}
+
+type PayrollReportParams struct {
+	UserIds         string `json:"user_ids,omitempty"`
+	GroupIds        string `json:"group_ids,omitempty"`
+	StartDate       string `json:"start_date"`
+	EndDate         string `json:"end_date"`
+	IncludeZeroTime bool   `json:"include_zero_time"`
+}
+
+func (c *Client) PayrollReport(ctx context.Context, params PayrollReportParams) ([]PayrollReport, Supplemental, error) {
+	return postReport[PayrollReport](ctx, c, "/reports/payroll", params, "payroll_report")
+}
+
+type ProjectEstimate struct {
+	ProjectID           json.Number `json:"project_id" type:"string"`
+	JobcodeID           json.Number `json:"jobcode_id" type:"string"`
+	TotalClockedSeconds int         `json:"total_clocked_seconds"`
+}
+
+type ProjectEstimateReportParams struct {
+	ProjectIds string `json:"project_ids"`
+}
+
+func (c *Client) ProjectEstimateReport(ctx context.Context, params ProjectEstimateReportParams) ([]ProjectEstimate, Supplemental, error) {
+	return postReport[ProjectEstimate](ctx, c, "/reports/project_estimate", params, "project_estimate_report")
+}
@@ -0,0 +1,101 @@
+package qbtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ResponseData holds a page of results, items are kept in the order the API returned them
+type ResponseData[T any] struct {
+	Items            []T
+	More             bool
+	SupplementalData map[string]json.RawMessage
+}
+
+func (rd *ResponseData[T]) DecodeBody(r io.Reader, fieldName string) error {
+	var rawResults struct {
+		Results          map[string]json.RawMessage `json:"results"`
+		More             bool                       `json:"more"`
+		SupplementalData map[string]json.RawMessage `json:"supplemental_data"`
+	}
+	decoder := json.NewDecoder(r)
+	err := decoder.Decode(&rawResults)
+	if err != nil {
+		return err
+	}
+	rd.More = rawResults.More
+	rd.SupplementalData = rawResults.SupplementalData
+
+	// Extract the items using the dynamic field name
+	itemsData, ok := rawResults.Results[fieldName]
+	if !ok {
+		return fmt.Errorf("expected field '%s' not found in results", fieldName)
+	}
+	items, err := decodeOrdered[T](itemsData)
+	if err != nil {
+		return err
+	}
+	rd.Items = items
+	return nil
+}
+
+func (rd *ResponseData[T]) ExtractItems() ([]T, bool) {
+	items := make([]T, len(rd.Items))
+	copy(items, rd.Items)
+	return items, rd.More
+}
+
+// DecodeSupplemental decodes a single supplemental_data section, a missing section returns no items
+func DecodeSupplemental[T any](sections map[string]json.RawMessage, name string) ([]T, error) {
+	data, ok := sections[name]
+	if !ok {
+		return nil, nil
+	}
+	items, err := decodeOrdered[T](data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode supplemental %s: %w", name, err)
+	}
+	return items, nil
+}
+
+// decodeOrdered decodes a JSON object keyed by id into a slice, keeping the order of the keys.
+// The API returns an empty array instead of an object when there are no results
+func decodeOrdered[T any](data json.RawMessage) ([]T, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case nil:
+		return nil, nil
+	case json.Delim('{'):
+	case json.Delim('['):
+		var items []T
+		err = json.Unmarshal(data, &items)
+		return items, err
+	default:
+		return nil, fmt.Errorf("unexpected token %v, expected object", token)
+	}
+
+	var items []T
+	for decoder.More() {
+		// skip the id key, each item carries its own id
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		var item T
+		if err := decoder.Decode(&item); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,114 @@
+package qbtime
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testItem struct {
+	Id   json.Number `json:"id"`
+	Name string      `json:"name"`
+}
+
+func TestDecodeOrdered(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []testItem
+		wantErr bool
+	}{
+		{
+			name: "object keeps key order",
+			data: `{"30": {"id": 30, "name": "c"}, "4": {"id": 4, "name": "a"}, "12": {"id": 12, "name": "b"}}`,
+			want: []testItem{{"30", "c"}, {"4", "a"}, {"12", "b"}},
+		},
+		{
+			name: "empty object",
+			data: `{}`,
+			want: nil,
+		},
+		{
+			name: "empty array",
+			data: `[]`,
+			want: nil,
+		},
+		{
+			name: "array",
+			data: `[{"id": 2, "name": "b"}, {"id": 1, "name": "a"}]`,
+			want: []testItem{{"2", "b"}, {"1", "a"}},
+		},
+		{
+			name: "null",
+			data: `null`,
+			want: nil,
+		},
+		{
+			name:    "string",
+			data:    `"users"`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid item",
+			data:    `{"1": {"id": "one"}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeOrdered[testItem](json.RawMessage(tt.data))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("decodeOrdered() = %v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeOrdered() error: %v", err)
+			}
+			if len(got) != len(tt.want) || (len(got) > 0 && !reflect.DeepEqual(got, tt.want)) {
+				t.Errorf("decodeOrdered() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeBody(t *testing.T) {
+	body := `{
+		"results": {"users": {"9": {"id": 9, "name": "z"}, "1": {"id": 1, "name": "a"}}},
+		"more": true,
+		"supplemental_data": {"groups": {"5": {"id": 5, "name": "g"}}}
+	}`
+
+	var response ResponseData[testItem]
+	if err := response.DecodeBody(strings.NewReader(body), "users"); err != nil {
+		t.Fatalf("DecodeBody() error: %v", err)
+	}
+
+	items, more := response.ExtractItems()
+	if want := []testItem{{"9", "z"}, {"1", "a"}}; !reflect.DeepEqual(items, want) {
+		t.Errorf("items = %v, want %v", items, want)
+	}
+	if !more {
+		t.Error("more = false, want true")
+	}
+
+	groups, err := DecodeSupplemental[testItem](response.SupplementalData, "groups")
+	if err != nil {
+		t.Fatalf("DecodeSupplemental() error: %v", err)
+	}
+	if want := []testItem{{"5", "g"}}; !reflect.DeepEqual(groups, want) {
+		t.Errorf("groups = %v, want %v", groups, want)
+	}
+
+	jobcodes, err := DecodeSupplemental[testItem](response.SupplementalData, "jobcodes")
+	if err != nil || jobcodes != nil {
+		t.Errorf("DecodeSupplemental() of a missing section = %v, %v, want no items", jobcodes, err)
+	}
+
+	if err := response.DecodeBody(strings.NewReader(body), "jobcodes"); err == nil {
+		t.Error("DecodeBody() with a missing field succeeded, want an error")
+	}
+}
@@ -0,0 +1,150 @@
+package qbtime
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		min    time.Duration
+		max    time.Duration
+		wantOk bool
+	}{
+		{name: "empty", header: ""},
+		{name: "seconds", header: "3", min: 3 * time.Second, max: 3 * time.Second, wantOk: true},
+		{name: "zero seconds", header: "0", wantOk: true},
+		{name: "negative seconds", header: "-1"},
+		{name: "http date", header: time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat), min: 8 * time.Second, max: 10 * time.Second, wantOk: true},
+		{name: "past http date", header: time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), wantOk: true},
+		{name: "invalid", header: "soon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, ok := retryAfter(tt.header)
+			if ok != tt.wantOk {
+				t.Fatalf("retryAfter(%q) ok = %v, want %v", tt.header, ok, tt.wantOk)
+			}
+			if wait < tt.min || wait > tt.max {
+				t.Errorf("retryAfter(%q) = %s, want between %s and %s", tt.header, wait, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		MinBackoff: 500 * time.Millisecond,
+		MaxBackoff: 8 * time.Second,
+	}
+
+	tests := []struct {
+		name       string
+		attempt    int
+		retryAfter string
+		max        time.Duration
+	}{
+		{name: "first attempt", attempt: 0, max: 500 * time.Millisecond},
+		{name: "doubles", attempt: 2, max: 2 * time.Second},
+		{name: "capped", attempt: 10, max: 8 * time.Second},
+		{name: "capped on overflow", attempt: 70, max: 8 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				wait := policy.backoff(tt.attempt, nil)
+				if wait < 0 || wait >= tt.max {
+					t.Fatalf("backoff(%d) = %s, want under %s", tt.attempt, wait, tt.max)
+				}
+			}
+		})
+	}
+
+	t.Run("retry after takes precedence", func(t *testing.T) {
+		res := &http.Response{Header: http.Header{"Retry-After": []string{"20"}}}
+		if wait := policy.backoff(0, res); wait != 20*time.Second {
+			t.Errorf("backoff() = %s, want 20s", wait)
+		}
+	})
+}
+
+func TestDoRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		status       int
+		wantAttempts int32
+	}{
+		{name: "get 5xx", method: http.MethodGet, status: http.StatusBadGateway, wantAttempts: 3},
+		{name: "get rate limited", method: http.MethodGet, status: http.StatusTooManyRequests, wantAttempts: 3},
+		{name: "get 4xx", method: http.MethodGet, status: http.StatusBadRequest, wantAttempts: 1},
+		{name: "post 5xx", method: http.MethodPost, status: http.StatusBadGateway, wantAttempts: 1},
+		{name: "post rate limited", method: http.MethodPost, status: http.StatusTooManyRequests, wantAttempts: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, server.Client())
+			client.Retry = RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Budget: time.Second}
+
+			req, err := client.newRequest(context.Background(), tt.method, "/", nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = client.do(req)
+			if err == nil {
+				t.Fatal("do() succeeded, want an error")
+			}
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("do() made %d attempts, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestDoStopsAtDeadline(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.Header().Set("Retry-After", "5")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, server.Client())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	req, err := client.newRequest(ctx, http.MethodGet, "/", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	_, err = client.do(req)
+	if !IsRateLimit(err) {
+		t.Fatalf("do() error = %v, want a rate limit error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("do() took %s, want it to stop before waiting past the deadline", elapsed)
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("do() made %d attempts, want 1", got)
+	}
+}
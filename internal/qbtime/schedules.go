@@ -0,0 +1,49 @@
+package qbtime
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type ScheduleCalendar struct {
+	Id   json.Number `json:"id" type:"string"`
+	Name string      `json:"name"`
+}
+
+type ScheduleEvent struct {
+	Id                 json.Number   `json:"id" type:"string"`
+	ScheduleCalendarID json.Number   `json:"schedule_calendar_id" type:"string"`
+	Start              string        `json:"start"`
+	End                string        `json:"end"`
+	AllDay             bool          `json:"all_day"`
+	AssignedUserIDs    []json.Number `json:"assigned_user_ids"`
+	JobcodeID          json.Number   `json:"jobcode_id" type:"string"`
+	Active             bool          `json:"active"`
+	Draft              bool          `json:"draft"`
+	Title              string        `json:"title"`
+	Notes              string        `json:"notes"`
+	Color              string        `json:"color"`
+}
+
+type ListScheduleCalendarsParams struct {
+	Page             int    `url:"page"`
+	SupplementalData string `url:"supplemental_data"`
+	ModifiedSince    string `url:"modified_since,omitempty"`
+}
+
+type ListScheduleEventsParams struct {
+	ScheduleCalendarIds string `url:"schedule_calendar_ids"`
+	Start               string `url:"start"`
+	Active              string `url:"active"`
+	Page                int    `url:"page"`
+	SupplementalData    string `url:"supplemental_data"`
+	ModifiedSince       string `url:"modified_since,omitempty"`
+}
+
+func (c *Client) ListScheduleCalendars(ctx context.Context, params ListScheduleCalendarsParams) ([]ScheduleCalendar, bool, error) {
+	return getList[ScheduleCalendar](ctx, c, "/schedule_calendars", &params, "schedule_calendars")
+}
+
+func (c *Client) ListScheduleEvents(ctx context.Context, params ListScheduleEventsParams) ([]ScheduleEvent, bool, error) {
+	return getList[ScheduleEvent](ctx, c, "/schedule_events", &params, "schedule_events")
+}
@@ -0,0 +1,110 @@
+package qbtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeLayout is the layout of times sent to the API, the offset is always written out so UTC is sent as +00:00 rather than Z
+const TimeLayout = "2006-01-02T15:04:05-07:00"
+
+// SettingValue is a setting decoded as text, settings are returned as strings, numbers or booleans
+type SettingValue string
+
+func (v *SettingValue) UnmarshalJSON(data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if value == nil {
+		*v = ""
+		return nil
+	}
+	*v = SettingValue(strings.TrimSpace(fmt.Sprint(value)))
+	return nil
+}
+
+// Bool reports whether the setting is on, settings are switched on with true, yes or 1
+func (v SettingValue) Bool() bool {
+	switch strings.ToLower(string(v)) {
+	case "true", "yes", "1":
+		return true
+	}
+	return false
+}
+
+// Float returns the setting as a number, 0 is used when the setting isn't a number
+func (v SettingValue) Float() float64 {
+	value, err := strconv.ParseFloat(string(v), 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// List returns the comma separated values of the setting
+func (v SettingValue) List() []string {
+	var values []string
+	for _, value := range strings.Split(string(v), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// EffectiveSettings are the settings in effect for a user after company and group settings are applied,
+// hours are returned as decimal hours
+type EffectiveSettings struct {
+	General struct {
+		TimeZone  SettingValue `json:"tz_string"`
+		WeekStart SettingValue `json:"week_start"`
+	} `json:"general"`
+	Overtime struct {
+		Calculate          SettingValue `json:"calculate_overtime"`
+		DailyRegularHours  SettingValue `json:"daily_regular_hours"`
+		DailyOvertimeHours SettingValue `json:"daily_overtime_hours"`
+		WeeklyRegularHours SettingValue `json:"weekly_regular_hours"`
+	} `json:"overtime"`
+	Timesheets struct {
+		RequireNotes         SettingValue `json:"require_notes"`
+		RequireJobcode       SettingValue `json:"require_jobcode"`
+		RequiredCustomFields SettingValue `json:"required_customfields"`
+	} `json:"timesheets"`
+}
+
+// Location returns the settings' time zone, UTC is used when the zone is empty or unknown
+func (s EffectiveSettings) Location() *time.Location {
+	if s.General.TimeZone == "" {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(string(s.General.TimeZone))
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// Weekday returns the first day of the week, Sunday is used when the setting is empty or unknown
+func (s EffectiveSettings) Weekday() time.Weekday {
+	value := strings.ToLower(string(s.General.WeekStart))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if value == strings.ToLower(day.String()) || value == strings.ToLower(day.String()[:3]) || value == fmt.Sprint(int(day)) {
+			return day
+		}
+	}
+	return time.Sunday
+}
+
+type effectiveSettingsParams struct {
+	UserID string `url:"user_id,omitempty"`
+}
+
+// EffectiveSettings returns the settings that apply to a user, the company settings are returned when userID is empty
+func (c *Client) EffectiveSettings(ctx context.Context, userID string) (EffectiveSettings, error) {
+	return getObject[EffectiveSettings](ctx, c, "/effective_settings", &effectiveSettingsParams{UserID: userID}, "effective_settings")
+}
@@ -0,0 +1,46 @@
+package qbtime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocation(t *testing.T) {
+	tests := []struct {
+		timeZone SettingValue
+		want     string
+	}{
+		{"", "UTC"},
+		{"Mars/Olympus", "UTC"},
+		{"UTC", "UTC"},
+	}
+
+	for _, tt := range tests {
+		var settings EffectiveSettings
+		settings.General.TimeZone = tt.timeZone
+		if got := settings.Location().String(); got != tt.want {
+			t.Errorf("Location() with %q = %s, want %s", tt.timeZone, got, tt.want)
+		}
+	}
+}
+
+func TestWeekday(t *testing.T) {
+	tests := []struct {
+		weekStart SettingValue
+		want      time.Weekday
+	}{
+		{"", time.Sunday},
+		{"Monday", time.Monday},
+		{"sat", time.Saturday},
+		{"3", time.Wednesday},
+		{"someday", time.Sunday},
+	}
+
+	for _, tt := range tests {
+		var settings EffectiveSettings
+		settings.General.WeekStart = tt.weekStart
+		if got := settings.Weekday(); got != tt.want {
+			t.Errorf("Weekday() with %q = %s, want %s", tt.weekStart, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package qbtime
+
+import "encoding/json"
+
+// Supplemental holds the related objects returned with a page when supplemental_data=yes, keyed by id
+type Supplemental struct {
+	Users           map[string]User
+	Groups          map[string]Group
+	Jobcodes        map[string]Jobcode
+	GeofenceConfigs map[string]GeofenceConfig
+}
+
+func NewSupplemental(sections map[string]json.RawMessage) (Supplemental, error) {
+	var supplemental Supplemental
+
+	users, err := DecodeSupplemental[User](sections, "users")
+	if err != nil {
+		return Supplemental{}, err
+	}
+	supplemental.Users = make(map[string]User, len(users))
+	for _, user := range users {
+		supplemental.Users[user.Id.String()] = user
+	}
+
+	groups, err := DecodeSupplemental[Group](sections, "groups")
+	if err != nil {
+		return Supplemental{}, err
+	}
+	supplemental.Groups = make(map[string]Group, len(groups))
+	for _, group := range groups {
+		supplemental.Groups[group.Id.String()] = group
+	}
+
+	jobcodes, err := DecodeSupplemental[Jobcode](sections, "jobcodes")
+	if err != nil {
+		return Supplemental{}, err
+	}
+	supplemental.Jobcodes = make(map[string]Jobcode, len(jobcodes))
+	for _, jobcode := range jobcodes {
+		supplemental.Jobcodes[jobcode.Id.String()] = jobcode
+	}
+
+	geofenceConfigs, err := DecodeSupplemental[GeofenceConfig](sections, "geofence_configs")
+	if err != nil {
+		return Supplemental{}, err
+	}
+	supplemental.GeofenceConfigs = make(map[string]GeofenceConfig, len(geofenceConfigs))
+	for _, geofenceConfig := range geofenceConfigs {
+		supplemental.GeofenceConfigs[geofenceConfig.Id.String()] = geofenceConfig
+	}
+
+	return supplemental, nil
+}
@@ -0,0 +1,49 @@
+package qbtime
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type TimeOffRequest struct {
+	Id     json.Number `json:"id" type:"string"`
+	UserID json.Number `json:"user_id" type:"string"`
+	Status string      `json:"status"`
+	Active bool        `json:"active"`
+}
+
+type TimeOffRequestEntry struct {
+	Id               json.Number `json:"id" type:"string"`
+	TimeOffRequestID json.Number `json:"time_off_request_id" type:"string"`
+	UserID           json.Number `json:"user_id" type:"string"`
+	JobcodeID        json.Number `json:"jobcode_id" type:"string"`
+	ApproverUserID   json.Number `json:"approver_user_id" type:"string"`
+	Status           string      `json:"status"`
+	Date             string      `json:"date"`
+	StartTime        string      `json:"start_time"`
+	EndTime          string      `json:"end_time"`
+	Duration         int         `json:"duration"`
+	EntryMethod      string      `json:"entry_method"`
+	Active           bool        `json:"active"`
+}
+
+type ListTimeOffRequestsParams struct {
+	Page             int    `url:"page"`
+	SupplementalData string `url:"supplemental_data"`
+	ModifiedSince    string `url:"modified_since,omitempty"`
+}
+
+type ListTimeOffRequestEntriesParams struct {
+	StartDate        string `url:"start_date,omitempty"`
+	Page             int    `url:"page"`
+	SupplementalData string `url:"supplemental_data"`
+	ModifiedSince    string `url:"modified_since,omitempty"`
+}
+
+func (c *Client) ListTimeOffRequests(ctx context.Context, params ListTimeOffRequestsParams) ([]TimeOffRequest, bool, error) {
+	return getList[TimeOffRequest](ctx, c, "/time_off_requests", &params, "time_off_requests")
+}
+
+func (c *Client) ListTimeOffRequestEntries(ctx context.Context, params ListTimeOffRequestEntriesParams) ([]TimeOffRequestEntry, bool, error) {
+	return getList[TimeOffRequestEntry](ctx, c, "/time_off_request_entries", &params, "time_off_request_entries")
+}
@@ -0,0 +1,53 @@
+package qbtime
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type Timesheet struct {
+	Id            json.Number       `json:"id" type:"string"`
+	UserID        json.Number       `json:"user_id" type:"string"`
+	JobcodeID     json.Number       `json:"jobcode_id" type:"string"`
+	Start         string            `json:"start"`
+	End           string            `json:"end"`
+	Duration      int               `json:"duration"`
+	Date          string            `json:"date"`
+	Type          string            `json:"type"`
+	OnTheClock    bool              `json:"on_the_clock"`
+	Notes         string            `json:"notes"`
+	AttachedFiles []json.Number     `json:"attached_files"`
+	CustomFields  CustomFieldValues `json:"customfields"`
+}
+
+type ListTimesheetsParams struct {
+	Ids              string `url:"ids,omitempty"`
+	UserIds          string `url:"user_ids,omitempty"`
+	GroupIds         string `url:"group_ids,omitempty"`
+	JobcodeIds       string `url:"jobcode_ids,omitempty"`
+	StartDate        string `url:"start_date"`
+	EndDate          string `url:"end_date,omitempty"`
+	OnTheClock       string `url:"on_the_clock"`
+	Page             int    `url:"page"`
+	SupplementalData string `url:"supplemental_data"`
+	ModifiedSince    string `url:"modified_since,omitempty"`
+}
+
+type DeletedTimesheet struct {
+	Id json.Number `json:"id" type:"string"`
+}
+
+type ListDeletedTimesheetsParams struct {
+	Page             int    `url:"page"`
+	SupplementalData string `url:"supplemental_data"`
+	ModifiedSince    string `url:"modified_since"`
+}
+
+// ListTimesheets returns a page of timesheets, the related users and jobcodes are included when SupplementalData is "yes"
+func (c *Client) ListTimesheets(ctx context.Context, params ListTimesheetsParams) ([]Timesheet, Supplemental, bool, error) {
+	return getListWithSupplemental[Timesheet](ctx, c, "/timesheets", &params, "timesheets")
+}
+
+func (c *Client) ListDeletedTimesheets(ctx context.Context, params ListDeletedTimesheetsParams) ([]DeletedTimesheet, bool, error) {
+	return getList[DeletedTimesheet](ctx, c, "/timesheets_deleted", &params, "timesheets_deleted")
+}
@@ -0,0 +1,88 @@
+package qbtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type User struct {
+	Id           json.Number       `json:"id" type:"string"`
+	Name         string            `json:"display_name"`
+	FirstName    string            `json:"first_name"`
+	LastName     string            `json:"last_name"`
+	Active       bool              `json:"active"`
+	LastActive   string            `json:"last_active"`
+	GroupID      json.Number       `json:"group_id" type:"string"`
+	Email        string            `json:"email"`
+	ApprovedTo   string            `json:"approved_to"`
+	SubmittedTo  string            `json:"submitted_to"`
+	CustomFields CustomFieldValues `json:"customfields"`
+}
+
+type ListUsersParams struct {
+	Ids              string `url:"ids,omitempty"`
+	GroupIds         string `url:"group_ids,omitempty"`
+	Active           string `url:"active"`
+	Page             int    `url:"page"`
+	Limit            int    `url:"limit,omitempty"`
+	SupplementalData string `url:"supplemental_data"`
+	ModifiedSince    string `url:"modified_since,omitempty"`
+}
+
+type CreateUser struct {
+	Username  string `json:"username"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	GroupID   int    `json:"group_id,omitempty"`
+}
+
+type CreateUserResult struct {
+	StatusCode    int         `json:"_status_code"`
+	StatusMessage string      `json:"_status_message"`
+	StatusExtra   string      `json:"_status_extra"`
+	Id            json.Number `json:"id" type:"string"`
+	FirstName     string      `json:"first_name"`
+	LastName      string      `json:"last_name"`
+}
+
+// ListUsers returns a page of users, the related groups are included when SupplementalData is "yes"
+func (c *Client) ListUsers(ctx context.Context, params ListUsersParams) ([]User, Supplemental, bool, error) {
+	return getListWithSupplemental[User](ctx, c, "/users", &params, "users")
+}
+
+func (c *Client) CurrentUser(ctx context.Context) (User, error) {
+	users, _, err := getList[User](ctx, c, "/current_user", nil, "users")
+	if err != nil {
+		return User{}, err
+	}
+
+	for _, user := range users {
+		return user, nil
+	}
+
+	return User{}, fmt.Errorf("no users in response")
+}
+
+// CreateUsers adds users to Quickbooks Time, failures are reported per user by the status fields of each result
+func (c *Client) CreateUsers(ctx context.Context, users []CreateUser) ([]CreateUserResult, error) {
+	type request struct {
+		Data []CreateUser `json:"data"`
+	}
+
+	res, err := c.postJSON(ctx, "/users", request{Data: users}, false)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var response ResponseData[CreateUserResult]
+	err = response.DecodeBody(res.Body, "users")
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode response: %w", err)
+	}
+
+	results, _ := response.ExtractItems()
+	return results, nil
+}
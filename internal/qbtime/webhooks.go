@@ -0,0 +1,83 @@
+package qbtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type Webhook struct {
+	Id          json.Number `json:"id" type:"string"`
+	Name        string      `json:"name"`
+	URL         string      `json:"url"`
+	Enabled     bool        `json:"enabled"`
+	ObjectTypes []string    `json:"object_types"`
+}
+
+type CreateWebhook struct {
+	Name        string   `json:"name"`
+	URL         string   `json:"url"`
+	Enabled     bool     `json:"enabled"`
+	ObjectTypes []string `json:"object_types"`
+}
+
+type CreateWebhookResult struct {
+	StatusCode    int    `json:"_status_code"`
+	StatusMessage string `json:"_status_message"`
+	StatusExtra   string `json:"_status_extra"`
+	Webhook
+}
+
+// WebhookPayload is the body Quickbooks Time posts to a webhook url, each event carries the id of the changed object
+type WebhookPayload struct {
+	Data []WebhookEvent `json:"data"`
+}
+
+type WebhookEvent struct {
+	Type   string `json:"type"`
+	Action string `json:"action"`
+	Object struct {
+		Id json.Number `json:"id" type:"string"`
+	} `json:"object"`
+}
+
+// CreateWebhooks registers webhooks, failures are reported per webhook by the status fields of each result
+func (c *Client) CreateWebhooks(ctx context.Context, webhooks []CreateWebhook) ([]CreateWebhookResult, error) {
+	type request struct {
+		Data []CreateWebhook `json:"data"`
+	}
+
+	res, err := c.postJSON(ctx, "/webhooks", request{Data: webhooks}, false)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var response ResponseData[CreateWebhookResult]
+	err = response.DecodeBody(res.Body, "webhooks")
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode response: %w", err)
+	}
+
+	results, _ := response.ExtractItems()
+	return results, nil
+}
+
+func (c *Client) DeleteWebhooks(ctx context.Context, ids string) error {
+	type params struct {
+		Ids string `url:"ids"`
+	}
+
+	req, err := c.newRequest(ctx, "DELETE", "/webhooks", &params{Ids: ids}, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	return nil
+}
@@ -7,26 +7,37 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/tommyhedley/fiberytsheets/internal/handlers"
+	"github.com/tommyhedley/fiberytsheets/internal/handlers/automations"
 	"github.com/tommyhedley/fiberytsheets/internal/handlers/oauth2"
 	"github.com/tommyhedley/fiberytsheets/internal/handlers/synchronizer"
+	"github.com/tommyhedley/fiberytsheets/internal/qbtime"
 )
 
 func main() {
 	godotenv.Load()
 
 	port := os.Getenv("PORT")
+	client := qbtime.NewClient(os.Getenv("TSHEETS_API_URL"), http.DefaultClient)
+
 	mux := http.NewServeMux()
 	mux.HandleFunc("GET /", handlers.Config)
 	mux.HandleFunc("GET /logo", handlers.Logo)
 
-	mux.HandleFunc("POST /oauth2/v1/authorize", oauth2.AuthorizeHandler)
-	mux.HandleFunc("POST /oauth2/v1/access_token", oauth2.TokenHandler)
-	mux.HandleFunc("POST /validate", oauth2.ValidateHandler)
+	mux.HandleFunc("POST /oauth2/v1/authorize", oauth2.AuthorizeHandler(client))
+	mux.HandleFunc("POST /oauth2/v1/access_token", oauth2.TokenHandler(client))
+	mux.HandleFunc("POST /validate", oauth2.ValidateHandler(client))
 
 	mux.HandleFunc("POST /api/v1/synchronizer/config", synchronizer.Config)
-	mux.HandleFunc("POST /api/v1/synchronizer/schema", synchronizer.Schema)
+	mux.HandleFunc("POST /api/v1/synchronizer/schema", synchronizer.Schema(client))
 	mux.HandleFunc("POST /api/v1/synchronizer/filter/validate", synchronizer.ValidateFilters)
-	mux.HandleFunc("POST /api/v1/synchronizer/data", synchronizer.Data)
+	mux.HandleFunc("POST /api/v1/synchronizer/data", synchronizer.Data(client))
+	mux.HandleFunc("POST /api/v1/synchronizer/datalist", synchronizer.Datalist(client))
+	mux.HandleFunc("POST /api/v1/synchronizer/resource", synchronizer.Resource(client))
+	mux.HandleFunc("POST /api/v1/synchronizer/webhooks", synchronizer.WebhookInstall(client))
+	mux.HandleFunc("POST /api/v1/synchronizer/webhooks/delete", synchronizer.WebhookUninstall(client))
+	mux.HandleFunc("POST /api/v1/synchronizer/webhooks/transform", synchronizer.WebhookTransform(client))
+
+	mux.HandleFunc("POST /api/v1/automations/action/execute", automations.Execute(client))
 
 	srv := &http.Server{
 		Addr:    ":" + port,